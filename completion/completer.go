@@ -0,0 +1,33 @@
+package completion
+
+import "strings"
+
+// Completer offers completions for the input being typed when Tab is pressed in the line editor
+type Completer interface {
+	// Complete returns the candidates for the input with the cursor at the given rune offset, and the rune offset
+	// where the text replaced by a candidate starts; A candidate replaces input[start:cursor]
+	Complete(input string, cursor int) (candidates []string, start int)
+}
+
+// CompleterFunc adapts an ordinary function to the Completer interface
+type CompleterFunc func(input string, cursor int) ([]string, int)
+
+// Complete calls f(input, cursor)
+func (f CompleterFunc) Complete(input string, cursor int) ([]string, int) {
+	return f(input, cursor)
+}
+
+// MakeListCompleter returns a Completer which completes the whole input from a fixed list of words, ie: hostnames
+// from an inventory or git branches
+func MakeListCompleter(words ...string) Completer {
+	return CompleterFunc(func(input string, cursor int) ([]string, int) {
+		prefix := string([]rune(input)[:cursor])
+		var ret []string
+		for _, w := range words {
+			if strings.HasPrefix(w, prefix) {
+				ret = append(ret, w)
+			}
+		}
+		return ret, 0
+	})
+}
@@ -0,0 +1,40 @@
+package completion
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompleterFunc_Complete(t *testing.T) {
+	f := CompleterFunc(func(input string, cursor int) ([]string, int) {
+		return []string{input}, cursor
+	})
+	got, start := f.Complete("abc", 2)
+	if !reflect.DeepEqual(got, []string{"abc"}) || start != 2 {
+		t.Errorf("Complete() = %v, %v, want %v, %v", got, start, []string{"abc"}, 2)
+	}
+}
+
+func TestMakeListCompleter(t *testing.T) {
+	c := MakeListCompleter("main", "master", "develop", "mañana")
+	tests := []struct {
+		name   string
+		input  string
+		cursor int
+		want   []string
+	}{
+		{name: "Prefix", input: "ma", cursor: 2, want: []string{"main", "master", "mañana"}},
+		{name: "Only text before cursor", input: "mxxx", cursor: 1, want: []string{"main", "master", "mañana"}},
+		{name: "Multi-byte prefix", input: "mañ", cursor: 3, want: []string{"mañana"}},
+		{name: "Empty", input: "", cursor: 0, want: []string{"main", "master", "develop", "mañana"}},
+		{name: "No match", input: "x", cursor: 1, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, start := c.Complete(tt.input, tt.cursor)
+			if !reflect.DeepEqual(got, tt.want) || start != 0 {
+				t.Errorf("Complete() = %v, %v, want %v, 0", got, start, tt.want)
+			}
+		})
+	}
+}
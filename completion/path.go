@@ -0,0 +1,93 @@
+package completion
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PathCompleter completes file system paths. The text before the cursor is taken as the path, so paths may contain
+// spaces; A leading "~" and environment variables are expanded to find the directory to list but are left as typed
+type PathCompleter struct {
+	OnlyDirectories bool     // If set, only directories are offered
+	Extensions      []string // If set, only files with one of these extensions, ie: ".yaml", are offered. Directories are always offered so they can be descended into
+	ShowHidden      bool     // By default, dot files are only offered once a "." has been typed. Setting ShowHidden always offers them
+}
+
+// Complete implements Completer. Directories are offered with a trailing separator
+func (c *PathCompleter) Complete(input string, cursor int) ([]string, int) {
+	typed := string([]rune(input)[:cursor])
+	dir, prefix := splitPath(typed)
+	start := cursor - len([]rune(prefix))
+
+	listDir := "."
+	if dir != "" {
+		expanded, err := ExpandPath(dir)
+		if err != nil {
+			return nil, start
+		}
+		listDir = expanded
+	}
+	entries, err := os.ReadDir(listDir)
+	if err != nil {
+		return nil, start
+	}
+
+	var ret []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".") && !c.ShowHidden {
+			continue
+		}
+		// Stat rather than the dir entry itself so symlinks to directories can be descended into
+		info, err := os.Stat(filepath.Join(listDir, name))
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			ret = append(ret, name+string(filepath.Separator))
+			continue
+		}
+		if c.OnlyDirectories || !c.hasAllowedExtension(name) {
+			continue
+		}
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret, start
+}
+
+func (c *PathCompleter) hasAllowedExtension(name string) bool {
+	if len(c.Extensions) == 0 {
+		return true
+	}
+	for _, ext := range c.Extensions {
+		if strings.EqualFold(filepath.Ext(name), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandPath expands a leading "~" to the user's home directory and replaces $VAR / ${VAR} environment variables
+func ExpandPath(path string) (string, error) {
+	path = os.ExpandEnv(path)
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = home + path[1:]
+	}
+	return path, nil
+}
+
+// splitPath splits a typed path after its last separator
+func splitPath(path string) (string, string) {
+	i := strings.LastIndexAny(path, "/"+string(filepath.Separator))
+	return path[:i+1], path[i+1:]
+}
@@ -0,0 +1,135 @@
+package completion
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func makePathTestDir(t *testing.T) string {
+	dir := t.TempDir()
+	for _, d := range []string{"configs", "cache", ".git"} {
+		os.Mkdir(filepath.Join(dir, d), 0755)
+	}
+	for _, f := range []string{"config.yaml", "config.json", ".env", filepath.Join("configs", "app.yaml")} {
+		os.WriteFile(filepath.Join(dir, f), nil, 0644)
+	}
+	os.Symlink(filepath.Join(dir, "configs"), filepath.Join(dir, "conflink"))
+	return dir
+}
+
+func TestPathCompleter_Complete(t *testing.T) {
+	dir := makePathTestDir(t)
+	sep := string(filepath.Separator)
+	tests := []struct {
+		name      string
+		completer PathCompleter
+		input     string
+		want      []string
+		wantStart int
+	}{
+		{
+			name:      "Files and directories",
+			input:     dir + sep + "con",
+			want:      []string{"config.json", "config.yaml", "configs" + sep, "conflink" + sep},
+			wantStart: len(dir) + 1,
+		},
+		{
+			name:      "Only directories",
+			completer: PathCompleter{OnlyDirectories: true},
+			input:     dir + sep + "c",
+			want:      []string{"cache" + sep, "configs" + sep, "conflink" + sep},
+			wantStart: len(dir) + 1,
+		},
+		{
+			name:      "Extensions",
+			completer: PathCompleter{Extensions: []string{".YAML"}},
+			input:     dir + sep + "conf",
+			want:      []string{"config.yaml", "configs" + sep, "conflink" + sep},
+			wantStart: len(dir) + 1,
+		},
+		{
+			name:      "Hidden only once typed",
+			input:     dir + sep + ".",
+			want:      []string{".env", ".git" + sep},
+			wantStart: len(dir) + 1,
+		},
+		{
+			name:      "Hidden skipped",
+			input:     dir + sep,
+			want:      []string{"cache" + sep, "config.json", "config.yaml", "configs" + sep, "conflink" + sep},
+			wantStart: len(dir) + 1,
+		},
+		{
+			name:      "Show hidden",
+			completer: PathCompleter{ShowHidden: true, OnlyDirectories: true},
+			input:     dir + sep,
+			want:      []string{".git" + sep, "cache" + sep, "configs" + sep, "conflink" + sep},
+			wantStart: len(dir) + 1,
+		},
+		{
+			name:      "Nested",
+			input:     dir + sep + "configs" + sep + "a",
+			want:      []string{"app.yaml"},
+			wantStart: len(dir) + len(sep+"configs"+sep),
+		},
+		{
+			name:      "Missing directory",
+			input:     dir + sep + "missing" + sep + "a",
+			want:      nil,
+			wantStart: len(dir) + len(sep+"missing"+sep),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, start := tt.completer.Complete(tt.input, len([]rune(tt.input)))
+			if !reflect.DeepEqual(got, tt.want) || start != tt.wantStart {
+				t.Errorf("Complete() = %v, %v, want %v, %v", got, start, tt.want, tt.wantStart)
+			}
+		})
+	}
+}
+
+func TestPathCompleter_RelativeAndHome(t *testing.T) {
+	dir := makePathTestDir(t)
+	t.Setenv("HOME", dir)
+	t.Setenv("CONF_DIR", filepath.Join(dir, "configs"))
+
+	c := &PathCompleter{}
+	if got, _ := c.Complete("~/cac", 5); !reflect.DeepEqual(got, []string{"cache" + string(filepath.Separator)}) {
+		t.Errorf("Complete() = %v, want cache directory", got)
+	}
+	if got, _ := c.Complete("$CONF_DIR/", 10); !reflect.DeepEqual(got, []string{"app.yaml"}) {
+		t.Errorf("Complete() = %v, want app.yaml", got)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(dir)
+	if got, start := c.Complete("cac", 3); !reflect.DeepEqual(got, []string{"cache" + string(filepath.Separator)}) || start != 0 {
+		t.Errorf("Complete() = %v, %v, want cache directory at 0", got, start)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/bob")
+	t.Setenv("PROJECT", "demo")
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "~", want: "/home/bob"},
+		{path: "~/x", want: "/home/bob/x"},
+		{path: "$HOME/${PROJECT}/x", want: "/home/bob/demo/x"},
+		{path: "~bob/x", want: "~bob/x"},
+		{path: "/etc", want: "/etc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got, err := ExpandPath(tt.path); err != nil || got != tt.want {
+				t.Errorf("ExpandPath() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
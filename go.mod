@@ -2,6 +2,7 @@ module github.com/bchivari/go-cli-prompt
 
 go 1.17
 
-require golang.org/x/term v0.0.0-20210927222741-03fcf44c2211
-
-require golang.org/x/sys v0.0.0-20210809222454-d867a43fc93e // indirect
+require (
+	golang.org/x/sys v0.0.0-20210809222454-d867a43fc93e
+	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211
+)
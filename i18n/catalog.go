@@ -0,0 +1,195 @@
+// Package i18n translates the built-in messages of the prompt and validation packages. The locale is detected from
+// LC_ALL, LC_MESSAGES and LANG unless set with SetLocale, and bundles for further locales, or replacing built-in
+// messages, are added with Register
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	FallbackLocale   = "en" // Messages missing from the bundles of a locale are taken from this locale
+	localeCodeset    = "."
+	localeModifier   = "@"
+	localeSeparator  = "_"
+	localeAltSep     = "-"
+	posixLocale      = "c"
+	posixLocaleAlias = "posix"
+)
+
+// Environment variables selecting the locale, in order of precedence
+var localeEnvVars = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// MessageID identifies a built-in message, ie: InvalidInput. Messages with arguments are fmt templates
+type MessageID string
+
+// Bundle holds the translations of the built-in messages for a locale
+type Bundle map[MessageID]string
+
+// LoadBundle reads a Bundle from a local JSON file holding an object of message IDs to translations, ie:
+// {"prompt.invalid_input": "Ungültige Eingabe"}
+func LoadBundle(name string) (Bundle, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("cannot parse bundle %v: %w", name, err)
+	}
+	return b, nil
+}
+
+// Catalog holds the bundles of every locale; Safe for concurrent use
+type Catalog struct {
+	mu      sync.RWMutex
+	bundles map[string]Bundle
+	locale  string // Set with SetLocale; Detected from the environment if empty
+}
+
+// NewCatalog returns a Catalog holding the built-in bundles: English, German and Japanese
+func NewCatalog() *Catalog {
+	c := &Catalog{bundles: make(map[string]Bundle)}
+	c.Register("en", English)
+	c.Register("de", German)
+	c.Register("ja", Japanese)
+	return c
+}
+
+// DefaultCatalog is used by the package level functions, and by the prompt and validation packages
+var DefaultCatalog = NewCatalog()
+
+// Register adds the messages of b to the bundle of locale, replacing messages already registered. Use a language,
+// ie: "de", for messages of every region, or a language and region, ie: "de_CH", for regional variants
+func (c *Catalog) Register(locale string, b Bundle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := normalizeLocale(locale)
+	if c.bundles[key] == nil {
+		c.bundles[key] = make(Bundle)
+	}
+	for id, text := range b {
+		c.bundles[key][id] = text
+	}
+}
+
+// SetLocale sets the locale of Localizers created for an empty locale; An empty locale restores detection from the
+// environment
+func (c *Catalog) SetLocale(locale string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.locale = locale
+}
+
+// Locale returns the locale set with SetLocale, or the one detected from the environment
+func (c *Catalog) Locale() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.locale != "" {
+		return c.locale
+	}
+	return DetectLocale()
+}
+
+// Localizer returns a Localizer translating to locale; The locale of the Catalog if empty
+func (c *Catalog) Localizer(locale string) Localizer {
+	return Localizer{catalog: c, locale: locale}
+}
+
+// lookup returns the message of the first of the locales which has it
+func (c *Catalog) lookup(id MessageID, locales []string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, l := range locales {
+		if text, ok := c.bundles[l][id]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// Localizer translates messages to a locale
+type Localizer struct {
+	catalog *Catalog
+	locale  string
+}
+
+// Message returns the translation of id, formatted with args if any. Messages missing for the locale fall back to
+// its language, then to FallbackLocale, then to the id itself
+func (l Localizer) Message(id MessageID, args ...interface{}) string {
+	catalog := l.catalog
+	if catalog == nil {
+		catalog = DefaultCatalog
+	}
+	locale := l.locale
+	if locale == "" {
+		locale = catalog.Locale()
+	}
+	text, ok := catalog.lookup(id, candidateLocales(locale))
+	if !ok {
+		text = string(id)
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Register adds b to the bundle of locale in the DefaultCatalog
+func Register(locale string, b Bundle) {
+	DefaultCatalog.Register(locale, b)
+}
+
+// SetLocale sets the locale of the DefaultCatalog; An empty locale restores detection from the environment
+func SetLocale(locale string) {
+	DefaultCatalog.SetLocale(locale)
+}
+
+// Default returns a Localizer translating to the locale of the DefaultCatalog
+func Default() Localizer {
+	return DefaultCatalog.Localizer("")
+}
+
+// T returns the translation of id to the locale of the DefaultCatalog, formatted with args if any
+func T(id MessageID, args ...interface{}) string {
+	return Default().Message(id, args...)
+}
+
+// DetectLocale returns the locale selected by LC_ALL, LC_MESSAGES or LANG, ie: "de_DE.UTF-8", or FallbackLocale if
+// none is set or the POSIX locale is selected
+func DetectLocale() string {
+	for _, name := range localeEnvVars {
+		if v := os.Getenv(name); v != "" {
+			if l := normalizeLocale(v); l == posixLocale || l == posixLocaleAlias {
+				return FallbackLocale
+			}
+			return v
+		}
+	}
+	return FallbackLocale
+}
+
+// normalizeLocale strips the codeset and modifier of locale and lower cases it, ie: "de-DE.UTF-8@euro" is "de_de"
+func normalizeLocale(locale string) string {
+	if i := strings.Index(locale, localeModifier); i >= 0 {
+		locale = locale[:i]
+	}
+	if i := strings.Index(locale, localeCodeset); i >= 0 {
+		locale = locale[:i]
+	}
+	return strings.ToLower(strings.ReplaceAll(locale, localeAltSep, localeSeparator))
+}
+
+// candidateLocales returns the bundles searched for locale, ie: "de_ch", "de", "en"
+func candidateLocales(locale string) []string {
+	l := normalizeLocale(locale)
+	ret := []string{l}
+	if i := strings.Index(l, localeSeparator); i > 0 {
+		ret = append(ret, l[:i])
+	}
+	return append(ret, FallbackLocale)
+}
@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestDetectLocale(t *testing.T) {
+	tests := []struct {
+		name       string
+		lcAll      string
+		lcMessages string
+		lang       string
+		want       string
+	}{
+		{name: "None", want: "en"},
+		{name: "LANG", lang: "de_DE.UTF-8", want: "de_DE.UTF-8"},
+		{name: "LC_MESSAGES", lcMessages: "ja_JP.UTF-8", lang: "de_DE.UTF-8", want: "ja_JP.UTF-8"},
+		{name: "LC_ALL", lcAll: "de_CH", lcMessages: "ja_JP.UTF-8", lang: "en_US", want: "de_CH"},
+		{name: "POSIX", lang: "C.UTF-8", want: "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", tt.lcMessages)
+			t.Setenv("LANG", tt.lang)
+			if got := DetectLocale(); got != tt.want {
+				t.Errorf("DetectLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizer_Message(t *testing.T) {
+	c := NewCatalog()
+	c.Register("de_CH", Bundle{PasswordMismatch: "Die Eingaben stimmen nicht überein (CH)"})
+	c.Register("fr", Bundle{InvalidInput: "Saisie invalide"})
+	tests := []struct {
+		name   string
+		locale string
+		id     MessageID
+		args   []interface{}
+		want   string
+	}{
+		{name: "English", locale: "en_US.UTF-8", id: InvalidInput, want: "Invalid Input"},
+		{name: "German", locale: "de_DE.UTF-8@euro", id: InvalidInput, want: "Ungültige Eingabe"},
+		{name: "Japanese", locale: "ja-JP", id: InvalidInput, want: "入力が無効です"},
+		{name: "Region", locale: "de_CH", id: PasswordMismatch, want: "Die Eingaben stimmen nicht überein (CH)"},
+		{name: "Language of region", locale: "de_CH", id: InvalidInput, want: "Ungültige Eingabe"},
+		{name: "Registered", locale: "fr_FR", id: InvalidInput, want: "Saisie invalide"},
+		{name: "Fallback", locale: "fr_FR", id: PasswordMismatch, want: "Entries do not match"},
+		{name: "Arguments", locale: "de", id: ConfirmPrompt, args: []interface{}{"Passwort"}, want: "Passwort bestätigen"},
+		{name: "Unknown", locale: "de", id: "custom.missing", want: "custom.missing"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.Localizer(tt.locale).Message(tt.id, tt.args...); got != tt.want {
+				t.Errorf("Message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_SetLocale(t *testing.T) {
+	c := NewCatalog()
+	t.Setenv("LC_ALL", "ja_JP.UTF-8")
+	if got := c.Localizer("").Message(InvalidInput); got != "入力が無効です" {
+		t.Errorf("Message() = %q, want the detected locale", got)
+	}
+	c.SetLocale("de")
+	if got := c.Localizer("").Message(InvalidInput); got != "Ungültige Eingabe" {
+		t.Errorf("Message() = %q, want the set locale", got)
+	}
+	if got := c.Localizer("en").Message(InvalidInput); got != "Invalid Input" {
+		t.Errorf("Message() = %q, want the locale of the Localizer", got)
+	}
+}
+
+func TestLoadBundle(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "fr.json")
+	os.WriteFile(name, []byte(`{"prompt.invalid_input": "Saisie invalide"}`), 0o600)
+	b, err := LoadBundle(name)
+	if err != nil || b[InvalidInput] != "Saisie invalide" {
+		t.Fatalf("LoadBundle() = %v, %v", b, err)
+	}
+	os.WriteFile(name, []byte(`{`), 0o600)
+	if _, err := LoadBundle(name); err == nil {
+		t.Errorf("LoadBundle() error = nil, want parse error")
+	}
+	if _, err := LoadBundle(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Errorf("LoadBundle() error = nil, want missing file error")
+	}
+}
+
+func TestBuiltInBundles(t *testing.T) {
+	verbs := regexp.MustCompile(`%[.0-9]*[a-z]`)
+	for locale, b := range map[string]Bundle{"de": German, "ja": Japanese} {
+		for id, english := range English {
+			text, ok := b[id]
+			if !ok {
+				t.Errorf("%v bundle is missing %v", locale, id)
+				continue
+			}
+			if got, want := verbs.FindAllString(text, -1), verbs.FindAllString(english, -1); len(got) != len(want) {
+				t.Errorf("%v message %v has verbs %v, want %v", locale, id, got, want)
+			}
+		}
+	}
+}
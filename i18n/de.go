@@ -0,0 +1,49 @@
+package i18n
+
+// German is the built-in bundle of the "de" locale
+var German = Bundle{
+	InvalidInput:           "Ungültige Eingabe",
+	NullInput:              "leer",
+	DefaultNotSerializable: "Der Standardwert kann nicht umgewandelt werden, das sollte nicht passieren. %v",
+	ConfirmPrompt:          "%v bestätigen",
+	PasswordMismatch:       "Die Eingaben stimmen nicht überein",
+	EditorHint:             "Eingabetaste öffnet den Editor",
+	MultiLineSentinelHint:  "Mit einer Zeile, die nur %v enthält, oder Strg-D beenden",
+	MultiLineEOFHint:       "Mit Strg-D beenden",
+	SelectNoMatches:        "Keine Treffer",
+	SelectSourceError:      "Optionen können nicht geladen werden: %v",
+	SelectFooter:           "(%v-%v von %v)",
+	SelectMore:             "... %v weitere, zum Filtern tippen",
+	StrengthLabel:          "Stärke",
+	AccessibleAnswer:       "Antwort auf %v: %v",
+	AccessibleNoAnswer:     "Keine Antwort auf %v",
+	AccessibleSelectHint:   "Geben Sie eine Zahl von 1 bis %v oder Text zum Filtern der Optionen ein",
+	PathExistingAny:        "Erwartet wird ein vorhandener Pfad",
+	PathExistingFile:       "Erwartet wird eine vorhandene Datei",
+	PathExistingDirectory:  "Erwartet wird ein vorhandenes Verzeichnis",
+	PathCreatableAny:       "Erwartet wird ein anlegbarer Pfad",
+	PathCreatableFile:      "Erwartet wird eine anlegbare Datei",
+	PathCreatableDirectory: "Erwartet wird ein anlegbares Verzeichnis",
+	PathAny:                "Erwartet wird ein Pfad",
+	PathFile:               "Erwartet wird eine Datei",
+	PathDirectory:          "Erwartet wird ein Verzeichnis",
+	PathExtensions:         "%v mit der Endung %v",
+	ReverseSearch:          "(Rückwärtssuche)`%v': ",
+	FailedReverseSearch:    "(erfolglose Rückwärtssuche)`%v': ",
+
+	StrengthVeryWeak:   "Sehr schwach",
+	StrengthWeak:       "Schwach",
+	StrengthFair:       "Mittel",
+	StrengthStrong:     "Stark",
+	StrengthVeryStrong: "Sehr stark",
+	StrengthUnknown:    "Unbekannt",
+	PolicyError:        "Das Passwort %v",
+	PolicySeparator:    ", ",
+	PolicyMinLength:    "muss mindestens %v Zeichen lang sein",
+	PolicyLower:        "muss einen Kleinbuchstaben enthalten",
+	PolicyUpper:        "muss einen Großbuchstaben enthalten",
+	PolicyDigit:        "muss eine Ziffer enthalten",
+	PolicySymbol:       "muss ein Sonderzeichen enthalten",
+	PolicyEntropy:      "ist zu leicht zu erraten (geschätzt %.0f Bit Entropie, %.0f erforderlich)",
+	PolicyDenyList:     "ist ein häufig verwendetes Passwort",
+}
@@ -0,0 +1,49 @@
+package i18n
+
+// Japanese is the built-in bundle of the "ja" locale
+var Japanese = Bundle{
+	InvalidInput:           "入力が無効です",
+	NullInput:              "空",
+	DefaultNotSerializable: "既定値を変換できません。これは想定外のエラーです。%v",
+	ConfirmPrompt:          "%v（確認）",
+	PasswordMismatch:       "入力が一致しません",
+	EditorHint:             "Enter キーでエディターを起動",
+	MultiLineSentinelHint:  "%v だけの行、または Ctrl-D で終了",
+	MultiLineEOFHint:       "Ctrl-D で終了",
+	SelectNoMatches:        "一致する項目がありません",
+	SelectSourceError:      "選択肢を読み込めません: %v",
+	SelectFooter:           "(%v-%v / %v)",
+	SelectMore:             "... 他 %v 件、入力して絞り込み",
+	StrengthLabel:          "強度",
+	AccessibleAnswer:       "%vの回答: %v",
+	AccessibleNoAnswer:     "%vの回答なし",
+	AccessibleSelectHint:   "1から%vまでの番号、または絞り込む文字を入力してください",
+	PathExistingAny:        "既存のパスを指定してください",
+	PathExistingFile:       "既存のファイルを指定してください",
+	PathExistingDirectory:  "既存のディレクトリを指定してください",
+	PathCreatableAny:       "作成可能なパスを指定してください",
+	PathCreatableFile:      "作成可能なファイルを指定してください",
+	PathCreatableDirectory: "作成可能なディレクトリを指定してください",
+	PathAny:                "パスを指定してください",
+	PathFile:               "ファイルを指定してください",
+	PathDirectory:          "ディレクトリを指定してください",
+	PathExtensions:         "%v（拡張子: %v）",
+	ReverseSearch:          "(逆方向検索)`%v': ",
+	FailedReverseSearch:    "(逆方向検索: 該当なし)`%v': ",
+
+	StrengthVeryWeak:   "非常に弱い",
+	StrengthWeak:       "弱い",
+	StrengthFair:       "普通",
+	StrengthStrong:     "強い",
+	StrengthVeryStrong: "非常に強い",
+	StrengthUnknown:    "不明",
+	PolicyError:        "パスワードは%v",
+	PolicySeparator:    "、",
+	PolicyMinLength:    "%v 文字以上にしてください",
+	PolicyLower:        "小文字を含めてください",
+	PolicyUpper:        "大文字を含めてください",
+	PolicyDigit:        "数字を含めてください",
+	PolicySymbol:       "記号を含めてください",
+	PolicyEntropy:      "推測されやすすぎます（推定エントロピー %.0f ビット、必要 %.0f ビット）",
+	PolicyDenyList:     "よく使われるパスワードです",
+}
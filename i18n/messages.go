@@ -0,0 +1,100 @@
+package i18n
+
+// Messages of the prompt package
+const (
+	InvalidInput           MessageID = "prompt.invalid_input"            // Default InvalidInputMessage
+	NullInput              MessageID = "prompt.null_input"               // Echoed for empty input which isn't accepted
+	DefaultNotSerializable MessageID = "prompt.default_not_serializable" // %v: The error of OutputSerializerFunc
+	ConfirmPrompt          MessageID = "prompt.confirm"                  // %v: PromptMessage
+	PasswordMismatch       MessageID = "prompt.password_mismatch"
+	EditorHint             MessageID = "prompt.editor_hint"
+	MultiLineSentinelHint  MessageID = "prompt.multi_line_sentinel_hint" // %v: MultiLineSentinel
+	MultiLineEOFHint       MessageID = "prompt.multi_line_eof_hint"
+	SelectNoMatches        MessageID = "prompt.select_no_matches"
+	SelectSourceError      MessageID = "prompt.select_source_error" // %v: The error of OptionsSource
+	SelectFooter           MessageID = "prompt.select_footer"       // %v: First and last visible option, and the number of options
+	SelectMore             MessageID = "prompt.select_more"         // %v: The number of options not listed
+	StrengthLabel          MessageID = "prompt.strength_label"
+	AccessibleAnswer       MessageID = "prompt.accessible_answer"      // %v: PromptMessage and the accepted answer
+	AccessibleNoAnswer     MessageID = "prompt.accessible_no_answer"   // %v: PromptMessage
+	AccessibleSelectHint   MessageID = "prompt.accessible_select_hint" // %v: The number of listed options
+	PathExistingAny        MessageID = "prompt.path_existing_any"
+	PathExistingFile       MessageID = "prompt.path_existing_file"
+	PathExistingDirectory  MessageID = "prompt.path_existing_directory"
+	PathCreatableAny       MessageID = "prompt.path_creatable_any"
+	PathCreatableFile      MessageID = "prompt.path_creatable_file"
+	PathCreatableDirectory MessageID = "prompt.path_creatable_directory"
+	PathAny                MessageID = "prompt.path_any"
+	PathFile               MessageID = "prompt.path_file"
+	PathDirectory          MessageID = "prompt.path_directory"
+	PathExtensions         MessageID = "prompt.path_extensions"       // %v: One of the path messages, and the extensions
+	ReverseSearch          MessageID = "prompt.reverse_search"        // %v: The search query; Shown in front of the match
+	FailedReverseSearch    MessageID = "prompt.failed_reverse_search" // %v: The search query; Shown in front of the last match
+)
+
+// Messages of the validation package
+const (
+	StrengthVeryWeak   MessageID = "validation.strength_very_weak"
+	StrengthWeak       MessageID = "validation.strength_weak"
+	StrengthFair       MessageID = "validation.strength_fair"
+	StrengthStrong     MessageID = "validation.strength_strong"
+	StrengthVeryStrong MessageID = "validation.strength_very_strong"
+	StrengthUnknown    MessageID = "validation.strength_unknown"
+	PolicyError        MessageID = "validation.policy_error"      // %v: The failed rules joined with PolicySeparator
+	PolicySeparator    MessageID = "validation.policy_separator"  // Joins the failed rules of PolicyError
+	PolicyMinLength    MessageID = "validation.policy_min_length" // %v: The minimum length
+	PolicyLower        MessageID = "validation.policy_lower"
+	PolicyUpper        MessageID = "validation.policy_upper"
+	PolicyDigit        MessageID = "validation.policy_digit"
+	PolicySymbol       MessageID = "validation.policy_symbol"
+	PolicyEntropy      MessageID = "validation.policy_entropy" // %.0f: The estimated and the required bits of entropy
+	PolicyDenyList     MessageID = "validation.policy_deny_list"
+)
+
+// English is the built-in bundle of the "en" locale, and the FallbackLocale
+var English = Bundle{
+	InvalidInput:           "Invalid Input",
+	NullInput:              "null",
+	DefaultNotSerializable: "Default value cannot be serialized, This shouldn't happen. %v",
+	ConfirmPrompt:          "Confirm %v",
+	PasswordMismatch:       "Entries do not match",
+	EditorHint:             "Enter to launch editor",
+	MultiLineSentinelHint:  "End with a line containing only %v, or Ctrl-D",
+	MultiLineEOFHint:       "End with Ctrl-D",
+	SelectNoMatches:        "No matches",
+	SelectSourceError:      "Cannot load options: %v",
+	SelectFooter:           "(%v-%v of %v)",
+	SelectMore:             "... %v more, type to filter",
+	StrengthLabel:          "Strength",
+	AccessibleAnswer:       "Answered %v: %v",
+	AccessibleNoAnswer:     "No answer to %v",
+	AccessibleSelectHint:   "Enter a number from 1 to %v, or text to filter the options",
+	PathExistingAny:        "Expected an existing path",
+	PathExistingFile:       "Expected an existing file",
+	PathExistingDirectory:  "Expected an existing directory",
+	PathCreatableAny:       "Expected a creatable path",
+	PathCreatableFile:      "Expected a creatable file",
+	PathCreatableDirectory: "Expected a creatable directory",
+	PathAny:                "Expected a path",
+	PathFile:               "Expected a file",
+	PathDirectory:          "Expected a directory",
+	PathExtensions:         "%v ending in %v",
+	ReverseSearch:          "(reverse-i-search)`%v': ",
+	FailedReverseSearch:    "(failed reverse-i-search)`%v': ",
+
+	StrengthVeryWeak:   "Very weak",
+	StrengthWeak:       "Weak",
+	StrengthFair:       "Fair",
+	StrengthStrong:     "Strong",
+	StrengthVeryStrong: "Very strong",
+	StrengthUnknown:    "Unknown",
+	PolicyError:        "Password %v",
+	PolicySeparator:    ", ",
+	PolicyMinLength:    "must be at least %v characters",
+	PolicyLower:        "must contain a lowercase letter",
+	PolicyUpper:        "must contain an uppercase letter",
+	PolicyDigit:        "must contain a digit",
+	PolicySymbol:       "must contain a symbol",
+	PolicyEntropy:      "is too predictable (estimated %.0f bits of entropy, %.0f required)",
+	PolicyDenyList:     "is a commonly used password",
+}
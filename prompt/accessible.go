@@ -0,0 +1,38 @@
+package prompt
+
+import (
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"os"
+	"strconv"
+)
+
+const envAccessible = "ACCESSIBLE"
+
+// DetectAccessibleMode reports if the ACCESSIBLE environment variable requests accessible mode; Any value other than
+// empty, "0" or "false" enables it
+func DetectAccessibleMode() bool {
+	v := os.Getenv(envAccessible)
+	if v == "" {
+		return false
+	}
+	enabled, err := strconv.ParseBool(v)
+	return err != nil || enabled
+}
+
+// isAccessible reports if the prompt is in accessible mode, set with SetOption(WithAccessibleMode) or the ACCESSIBLE
+// environment variable. In accessible mode input is read as plain lines, a Select lists numbered options, nothing is
+// redrawn and no escape sequences are written, and every accepted answer is confirmed with a line of text
+func (h *Prompt) isAccessible() bool {
+	return h.accessible || DetectAccessibleMode()
+}
+
+// displayAccessibleAnswer confirms the accepted answer in words, as screen readers can't tell it was accepted from
+// the next prompt being displayed
+func (h *Prompt) displayAccessibleAnswer(answer string) {
+	text := h.message(i18n.AccessibleNoAnswer, h.PromptMessage)
+	if answer != "" {
+		text = h.message(i18n.AccessibleAnswer, h.PromptMessage, answer)
+	}
+	fmt.Fprintln(h.getOutputWriter(), text)
+}
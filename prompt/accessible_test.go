@@ -0,0 +1,110 @@
+package prompt
+
+import (
+	"bytes"
+	"github.com/bchivari/go-cli-prompt/validation"
+	"strings"
+	"testing"
+)
+
+func TestDetectAccessibleMode(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "", want: false},
+		{value: "0", want: false},
+		{value: "false", want: false},
+		{value: "1", want: true},
+		{value: "true", want: true},
+		{value: "yes", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv(envAccessible, tt.value)
+			if got := DetectAccessibleMode(); got != tt.want {
+				t.Errorf("DetectAccessibleMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompt_AccessibleMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   Prompt
+		input    string
+		want     interface{}
+		wantText string
+	}{
+		{
+			name:     "Answer is confirmed",
+			prompt:   Prompt{PromptMessage: "Name", Placeholder: "Bob", LiveValidation: true},
+			input:    "Alice\n",
+			want:     "Alice",
+			wantText: "Name: Answered Name: Alice\n",
+		},
+		{
+			name:     "Default is confirmed",
+			prompt:   Prompt{PromptMessage: "Name", DefaultAsString: "Bob"},
+			input:    "\n",
+			want:     "Bob",
+			wantText: "Name [Bob]: Answered Name: Bob\n",
+		},
+		{
+			name:     "No answer",
+			prompt:   Prompt{PromptMessage: "Name", AllowNil: true},
+			input:    "\n",
+			want:     nil,
+			wantText: "Name: No answer to Name\n",
+		},
+		{
+			name:     "Invalid input",
+			prompt:   Prompt{PromptMessage: "Age", InputValidatorFunc: func(s string) bool { return s == "42" }},
+			input:    "x\n42\n",
+			want:     "42",
+			wantText: "Age: \nInvalid Input [x]\n\nAge: Answered Age: 42\n",
+		},
+		{
+			name:     "Password strength is described",
+			prompt:   Prompt{PromptMessage: "Password", IsPassword: true, PasswordMask: '*', StrengthMeter: validation.EstimateStrength},
+			input:    "hunter2\n",
+			want:     "hunter2",
+			wantText: "Password: \nStrength: Fair\nAnswered Password: ********\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := tt.prompt
+			p.SetOptions(WithAccessibleMode(), WithReader(strings.NewReader(tt.input)), WithWriter(out),
+				WithLineEditor(), WithTheme(DefaultTheme), WithColorLevel(ColorLevelTrueColor), WithAnsweredSummary())
+			got, err := p.Show()
+			if err != nil {
+				t.Fatalf("Show() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Show() = %v, want %v", got, tt.want)
+			}
+			if out.String() != tt.wantText {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestSelect_AccessibleMode(t *testing.T) {
+	t.Setenv(envAccessible, "1")
+	out := new(bytes.Buffer)
+	s := &Select{Prompt: Prompt{PromptMessage: "Region"}, Options: MakeOptions("us-east-1", "eu-west-1")}
+	s.SetOptions(WithReader(strings.NewReader("2\n")), WithWriter(out), WithLineEditor(), WithTheme(DefaultTheme))
+	got, err := s.Show()
+	if err != nil || got != "eu-west-1" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "eu-west-1")
+	}
+	want := "  1) us-east-1\n  2) eu-west-1\nEnter a number from 1 to 2, or text to filter the options\nRegion: " +
+		"Answered Region: eu-west-1\n"
+	if out.String() != want {
+		t.Errorf("Show() output = %q, want %q", out.String(), want)
+	}
+}
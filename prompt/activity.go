@@ -0,0 +1,205 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	defaultActivityInterval    = 100 * time.Millisecond
+	defaultActivityLogInterval = 10 * time.Second
+)
+
+// activity draws a widget on the line of a terminal, redrawing it every interval, or writes a log line every
+// logInterval to other writers, until stopped. Prompts stop the activities running on their writer before they are
+// displayed, so the line is free for the prompt
+type activity struct {
+	mu          sync.Mutex
+	w           io.Writer
+	tty         bool // Set if the widget is drawn and redrawn on the line, otherwise it is logged
+	render      func() string
+	logLine     func(elapsed time.Duration) string // Returns the next log line; Nothing is written if empty
+	interval    time.Duration
+	logInterval time.Duration
+	started     time.Time
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// activities are the running activities, stopped when a prompt is displayed on the same writer
+var activities = struct {
+	sync.Mutex
+	running []*activity
+}{}
+
+// start draws or logs the widget once and keeps doing so every interval until stopped; Does nothing if already
+// running
+func (a *activity) start() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stop != nil {
+		return
+	}
+	a.tty = isTerminalWriter(a.w) && !DetectAccessibleMode()
+	a.started = time.Now()
+	a.stop, a.done = make(chan struct{}), make(chan struct{})
+	a.draw()
+
+	activities.Lock()
+	activities.running = append(activities.running, a)
+	activities.Unlock()
+
+	interval := a.logInterval
+	if a.tty {
+		interval = a.interval
+	}
+	go a.run(interval, a.stop, a.done)
+}
+
+func (a *activity) run(interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			a.mu.Lock()
+			// A tick may race with halt; Only draw while this run is the current one
+			if a.stop == stop {
+				a.draw()
+			}
+			a.mu.Unlock()
+		}
+	}
+}
+
+// draw redraws the widget on the line, or writes its log line; Must be called with mu held
+func (a *activity) draw() {
+	if !a.tty {
+		if line := a.logLine(time.Since(a.started)); line != "" {
+			fmt.Fprintln(a.w, line)
+		}
+		return
+	}
+	line := a.render()
+	if cols := terminalWidth(a.w); cols > 0 {
+		// A wrapped line couldn't be redrawn with a carriage return
+		line, _ = splitAtWidth(line, cols-1)
+	}
+	fmt.Fprint(a.w, "\r"+line+ansiClearToEnd)
+}
+
+// refresh redraws the widget on a terminal after its state changed, without waiting for the next interval
+func (a *activity) refresh() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stop != nil && a.tty {
+		a.draw()
+	}
+}
+
+// clearLine erases the widget so log output can be written in its place; Must be called with mu held
+func (a *activity) clearLine() {
+	if a.stop != nil && a.tty {
+		fmt.Fprint(a.w, "\r"+ansiClearToEnd)
+	}
+}
+
+// redrawLine draws the widget again after clearLine; Must be called with mu held
+func (a *activity) redrawLine() {
+	if a.stop != nil && a.tty {
+		a.draw()
+	}
+}
+
+// running reports if the activity was started and hasn't been stopped
+func (a *activity) running() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stop != nil
+}
+
+// halt stops the activity and erases the widget from a terminal; final, if not empty, is then written on its own
+// line. Does nothing if the activity isn't running. The activity is removed from the running activities in the same
+// critical section, so a concurrent start can't register it twice
+func (a *activity) halt(final string) {
+	a.mu.Lock()
+	stop, done := a.stop, a.done
+	if stop == nil {
+		a.mu.Unlock()
+		return
+	}
+	a.stop, a.done = nil, nil
+	activities.Lock()
+	for i, r := range activities.running {
+		if r == a {
+			activities.running = append(activities.running[:i], activities.running[i+1:]...)
+			break
+		}
+	}
+	activities.Unlock()
+	close(stop)
+	if a.tty {
+		fmt.Fprint(a.w, "\r"+ansiClearToEnd)
+	}
+	if final != "" {
+		fmt.Fprintln(a.w, final)
+	}
+	a.mu.Unlock()
+	<-done
+}
+
+// startActivity starts a new activity returned by build and stores it in current, unless the activity in current is
+// still running. build is called with mu, the lock guarding current, held; A new activity is built on every start so
+// changes to the widget's Writer or intervals since the last start apply
+func startActivity(mu sync.Locker, current **activity, build func() *activity) {
+	mu.Lock()
+	a := *current
+	mu.Unlock()
+	if a != nil && a.running() {
+		return
+	}
+	mu.Lock()
+	if *current != a {
+		// Another Start replaced it meanwhile
+		mu.Unlock()
+		return
+	}
+	a = build()
+	*current = a
+	mu.Unlock()
+	a.start()
+}
+
+// stopActivities stops the spinners and progress bars running on w, so they don't draw over a prompt
+func stopActivities(w io.Writer) {
+	activities.Lock()
+	var stopping []*activity
+	for _, a := range activities.running {
+		if sameWriter(a.w, w) {
+			stopping = append(stopping, a)
+		}
+	}
+	activities.Unlock()
+	for _, a := range stopping {
+		a.halt("")
+	}
+}
+
+// sameWriter reports if a and b are the same writer; Writers which can't be compared are never the same
+func sameWriter(a io.Writer, b io.Writer) bool {
+	if a == nil || b == nil || reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// stopActivities stops the spinners and progress bars running on the output writer before the prompt is displayed
+func (h *Prompt) stopActivities() {
+	stopActivities(h.getRawOutputWriter())
+}
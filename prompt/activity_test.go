@@ -0,0 +1,201 @@
+package prompt
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for the goroutine of an activity to write to
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.b.String()
+}
+
+func TestSpinner_render(t *testing.T) {
+	s := &Spinner{Message: "Deploying", Frames: []string{"-", "\\"}}
+	for _, want := range []string{"- Deploying", "\\ Deploying", "- Deploying"} {
+		if got := s.render(); got != want {
+			t.Errorf("render() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestSpinner_Log(t *testing.T) {
+	out := &syncBuffer{}
+	s := &Spinner{Message: "Deploying", Writer: out, LogInterval: time.Millisecond}
+	s.Start()
+	s.Start() // Already running
+	time.Sleep(20 * time.Millisecond)
+	s.StopWithMessage("Deployed")
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) < 3 {
+		t.Fatalf("Spinner output = %q, want periodic log lines", out.String())
+	}
+	if lines[0] != "Deploying..." || lines[1] != "Deploying..." || lines[len(lines)-1] != "Deployed" {
+		t.Errorf("Spinner output = %q, want log lines ending with the final message", out.String())
+	}
+	if strings.Contains(out.String(), "\x1b") {
+		t.Errorf("Spinner output = %q, want no escape sequences", out.String())
+	}
+}
+
+func TestSpinner_logLine(t *testing.T) {
+	s := &Spinner{Message: "Deploying"}
+	if got, want := s.logLine(0), "Deploying..."; got != want {
+		t.Errorf("logLine() = %q, want %q", got, want)
+	}
+	if got, want := s.logLine(61500*time.Millisecond), "Deploying... (1m2s)"; got != want {
+		t.Errorf("logLine() = %q, want %q", got, want)
+	}
+}
+
+func TestProgressBar_render(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		current int64
+		want    string
+	}{
+		{name: "Empty", total: 10, current: 0, want: "Copying [----------]   0%"},
+		{name: "Half", total: 10, current: 5, want: "Copying [#####-----]  50%"},
+		{name: "Complete", total: 10, current: 10, want: "Copying [##########] 100%"},
+		{name: "Unknown total", total: 0, current: 5, want: "Copying [----------]   0%"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &ProgressBar{Message: "Copying", Total: tt.total, Width: 10}
+			p.Set(tt.current)
+			if got := p.render(); got != tt.want {
+				t.Errorf("render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressBar_Log(t *testing.T) {
+	out := &syncBuffer{}
+	p := &ProgressBar{Message: "Copying", Total: 200, Writer: out, LogInterval: time.Hour}
+	p.Start()
+	p.Add(50)
+	p.Add(-100) // Clamped to 0
+	p.Add(250)  // Clamped to Total
+	p.Stop()
+	if want := "Copying: 0% (0/200)\nCopying: 100% (200/200)\n"; out.String() != want {
+		t.Errorf("ProgressBar output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestActivity_RestartUsesWriter(t *testing.T) {
+	first, second := &syncBuffer{}, &syncBuffer{}
+	s := &Spinner{Message: "Deploying", Writer: first, LogInterval: time.Hour}
+	s.Start()
+	s.Stop()
+	s.Writer = second
+	s.Start()
+	s.Stop()
+
+	p := &ProgressBar{Message: "Copying", Total: 10, Writer: first, LogInterval: time.Hour}
+	p.Start()
+	p.Stop()
+	p.Writer = second
+	p.Start()
+	p.Stop()
+
+	if want := "Deploying...\nCopying: 0% (0/10)\n"; first.String() != want || second.String() != want {
+		t.Errorf("output = %q and %q, want %q written to each Writer", first.String(), second.String(), want)
+	}
+}
+
+func TestActivity_ConcurrentStartHalt(t *testing.T) {
+	a := &activity{w: new(syncBuffer), render: func() string { return "" }, logLine: func(time.Duration) string { return "" },
+		interval: time.Millisecond, logInterval: time.Millisecond}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				a.start()
+				a.halt("")
+			}
+		}()
+	}
+	wg.Wait()
+	a.halt("")
+
+	activities.Lock()
+	defer activities.Unlock()
+	for _, r := range activities.running {
+		if r == a {
+			t.Fatalf("halted activity is still registered")
+		}
+	}
+}
+
+func TestPrompt_StopsActivities(t *testing.T) {
+	out := &syncBuffer{}
+	s := &Spinner{Message: "Loading", Writer: out, LogInterval: time.Millisecond}
+	s.Start()
+	other := &Spinner{Message: "Elsewhere", Writer: new(syncBuffer), LogInterval: time.Hour}
+	other.Start()
+	defer other.Stop()
+
+	p := &Prompt{PromptMessage: "Name"}
+	p.SetOptions(WithReader(strings.NewReader("Bob\n")), WithWriter(out))
+	if _, err := p.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	text := out.String()
+	time.Sleep(10 * time.Millisecond)
+	if out.String() != text || !strings.HasSuffix(text, "Name: ") {
+		t.Errorf("Show() output = %q, want the spinner stopped before the prompt", out.String())
+	}
+	activities.Lock()
+	running := len(activities.running)
+	activities.Unlock()
+	if running != 1 {
+		t.Errorf("%v activities running, want only the one on another writer", running)
+	}
+}
+
+func TestSameWriter(t *testing.T) {
+	a, b := new(bytes.Buffer), new(bytes.Buffer)
+	for _, tt := range []struct {
+		name string
+		a, b io.Writer
+		want bool
+	}{
+		{name: "Same", a: a, b: a, want: true},
+		{name: "Different", a: a, b: b, want: false},
+		{name: "Nil", a: a, b: nil, want: false},
+		{name: "Not comparable", a: sliceWriter{}, b: sliceWriter{}, want: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameWriter(tt.a, tt.b); got != tt.want {
+				t.Errorf("sameWriter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type sliceWriter []byte
+
+func (sliceWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
@@ -0,0 +1,318 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	envNoColor        = "NO_COLOR"
+	envTerm           = "TERM"
+	envColorTerm      = "COLORTERM"
+	termDumb          = "dumb"
+	term256ColorTag   = "256color"
+	colorTermTrue     = "truecolor"
+	colorTerm24Bit    = "24bit"
+	sgrTemplate       = "\x1b[%vm"
+	sgrSeparator      = ";"
+	sgrBold           = "1"
+	sgrFaint          = "2"
+	sgrItalic         = "3"
+	sgrUnderline      = "4"
+	sgrForeground     = 30
+	sgrBrightOffset   = 90 - 8 // Bright colors 8-15 use codes 90-97
+	sgr256Prefix      = "38;5;"
+	sgrRGBPrefix      = "38;2;"
+	basicColorCount   = 16
+	cubeOffset        = 16  // Index of the first color of the 6x6x6 cube of the 256 color palette
+	grayOffset        = 232 // Index of the first color of the grayscale ramp of the 256 color palette
+	brightThreshold   = 192 // Channel value above which an RGB color maps to a bright basic color
+	channelThreshold  = 128 // Channel value above which an RGB color includes that channel's basic color
+	grayRampStep      = 10
+	grayRampStart     = 8
+	cubeLevels        = 6
+	maxChannelValue   = 255
+	cubeChannelStep   = 40
+	cubeChannelOffset = 55
+)
+
+// ColorLevel is the range of colors a terminal displays
+type ColorLevel int
+
+const (
+	ColorLevelAuto      ColorLevel = iota // Detected from the output writer and environment with DetectColorLevel
+	ColorLevelNone                        // Plain text
+	ColorLevel16                          // The 16 basic ANSI colors
+	ColorLevel256                         // The 256 color palette
+	ColorLevelTrueColor                   // 24 bit RGB colors
+)
+
+// DetectColorLevel returns the colors w displays: ColorLevelNone if NO_COLOR is set or w isn't a terminal, otherwise
+// the level advertised by COLORTERM and TERM
+func DetectColorLevel(w io.Writer) ColorLevel {
+	if os.Getenv(envNoColor) != "" {
+		return ColorLevelNone
+	}
+	if !isTerminalWriter(w) {
+		return ColorLevelNone
+	}
+	return colorLevelFromEnv(os.Getenv(envTerm), os.Getenv(envColorTerm))
+}
+
+func colorLevelFromEnv(termName, colorTerm string) ColorLevel {
+	switch {
+	case termName == "" || termName == termDumb:
+		return ColorLevelNone
+	case colorTerm == colorTermTrue || colorTerm == colorTerm24Bit:
+		return ColorLevelTrueColor
+	case strings.Contains(termName, term256ColorTag):
+		return ColorLevel256
+	}
+	return ColorLevel16
+}
+
+type colorKind int
+
+const (
+	colorUnset colorKind = iota
+	colorBasic
+	colorIndexed
+	colorRGB
+)
+
+// Color is a foreground color; Create one with ANSI, ANSI256 or RGB. Colors the terminal can't display are
+// approximated with the nearest color it can
+type Color struct {
+	kind    colorKind
+	index   uint8
+	r, g, b uint8
+}
+
+// ANSI returns one of the 16 basic colors; 0-7 are the regular colors, 8-15 their bright variants
+func ANSI(n uint8) Color {
+	return Color{kind: colorBasic, index: n % basicColorCount}
+}
+
+// ANSI256 returns a color of the 256 color palette
+func ANSI256(n uint8) Color {
+	return Color{kind: colorIndexed, index: n}
+}
+
+// RGB returns a 24 bit color
+func RGB(r, g, b uint8) Color {
+	return Color{kind: colorRGB, r: r, g: g, b: b}
+}
+
+// The basic colors
+var (
+	Black       = ANSI(0)
+	Red         = ANSI(1)
+	Green       = ANSI(2)
+	Yellow      = ANSI(3)
+	Blue        = ANSI(4)
+	Magenta     = ANSI(5)
+	Cyan        = ANSI(6)
+	White       = ANSI(7)
+	BrightBlack = ANSI(8)
+)
+
+// sgr returns the SGR parameters selecting c at level, or "" if c is unset or no colors are displayed
+func (c Color) sgr(level ColorLevel) string {
+	switch {
+	case c.kind == colorUnset || level <= ColorLevelNone:
+		return ""
+	case c.kind == colorRGB && level >= ColorLevelTrueColor:
+		return sgrRGBPrefix + strconv.Itoa(int(c.r)) + sgrSeparator + strconv.Itoa(int(c.g)) + sgrSeparator + strconv.Itoa(int(c.b))
+	case c.kind == colorRGB && level == ColorLevel256:
+		return sgr256Prefix + strconv.Itoa(int(rgbTo256(c.r, c.g, c.b)))
+	case c.kind == colorIndexed && level >= ColorLevel256:
+		return sgr256Prefix + strconv.Itoa(int(c.index))
+	}
+	return basicSGR(c.toBasic())
+}
+
+// toBasic approximates c with one of the 16 basic colors
+func (c Color) toBasic() uint8 {
+	switch c.kind {
+	case colorIndexed:
+		if c.index < basicColorCount {
+			return c.index
+		}
+		r, g, b := indexedToRGB(c.index)
+		return rgbToBasic(r, g, b)
+	case colorRGB:
+		return rgbToBasic(c.r, c.g, c.b)
+	}
+	return c.index
+}
+
+func basicSGR(n uint8) string {
+	if n >= basicColorCount/2 {
+		return strconv.Itoa(sgrBrightOffset + int(n))
+	}
+	return strconv.Itoa(sgrForeground + int(n))
+}
+
+func rgbTo256(r, g, b uint8) uint8 {
+	scale := func(v uint8) int {
+		return (int(v)*(cubeLevels-1) + maxChannelValue/2) / maxChannelValue
+	}
+	return uint8(cubeOffset + cubeLevels*cubeLevels*scale(r) + cubeLevels*scale(g) + scale(b))
+}
+
+func indexedToRGB(n uint8) (uint8, uint8, uint8) {
+	if n >= grayOffset {
+		v := uint8(grayRampStart + grayRampStep*int(n-grayOffset))
+		return v, v, v
+	}
+	level := func(i int) uint8 {
+		if i == 0 {
+			return 0
+		}
+		return uint8(cubeChannelOffset + cubeChannelStep*i)
+	}
+	i := int(n) - cubeOffset
+	return level(i / (cubeLevels * cubeLevels)), level(i / cubeLevels % cubeLevels), level(i % cubeLevels)
+}
+
+func rgbToBasic(r, g, b uint8) uint8 {
+	var n uint8
+	if r >= channelThreshold {
+		n |= 1
+	}
+	if g >= channelThreshold {
+		n |= 2
+	}
+	if b >= channelThreshold {
+		n |= 4
+	}
+	if r >= brightThreshold || g >= brightThreshold || b >= brightThreshold {
+		n += basicColorCount / 2
+	}
+	return n
+}
+
+// Style is the color and attributes of displayed text
+type Style struct {
+	Foreground Color // If unset, the terminal's color is kept
+	Bold       bool
+	Faint      bool
+	Italic     bool
+	Underline  bool
+}
+
+// Render returns text wrapped in the escape sequences of the style at level; Plain text if level is ColorLevelNone.
+// ColorLevelAuto is treated as ColorLevelNone, detect the level with DetectColorLevel first
+func (s Style) Render(level ColorLevel, text string) string {
+	seq := s.sequence(level)
+	if seq == "" || text == "" {
+		return text
+	}
+	return seq + text + ansiReset
+}
+
+// sequence returns the escape sequence starting the style at level, or "" if it changes nothing
+func (s Style) sequence(level ColorLevel) string {
+	if level <= ColorLevelNone {
+		return ""
+	}
+	var params []string
+	for _, a := range []struct {
+		set bool
+		sgr string
+	}{{s.Bold, sgrBold}, {s.Faint, sgrFaint}, {s.Italic, sgrItalic}, {s.Underline, sgrUnderline}} {
+		if a.set {
+			params = append(params, a.sgr)
+		}
+	}
+	if c := s.Foreground.sgr(level); c != "" {
+		params = append(params, c)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(sgrTemplate, strings.Join(params, sgrSeparator))
+}
+
+// Theme styles the parts of a prompt; Set with SetOption(WithTheme)
+type Theme struct {
+	Label       Style // PromptMessage
+	Default     Style // DefaultAsString displayed in the prompt
+	Hint        Style // Instructions displayed with the prompt, ie: "End with Ctrl-D"
+	Error       Style // InvalidInputMessage and validation errors
+	Selected    Style // The selected option of a Select
+	Match       Style // The runes of Select options matched by the filter; Bold and underlined if no Theme is set
+	Summary     Style // The answer displayed once accepted
+	Placeholder Style // Placeholder displayed in the empty input; Faint if no Theme is set
+}
+
+// defaultMatchStyle highlights the runes matched by the filter of a Select if no Theme is set
+var defaultMatchStyle = Style{Bold: true, Underline: true}
+
+// Built-in themes
+var (
+	// DefaultTheme uses the basic colors, so it looks alike in every terminal
+	DefaultTheme = Theme{
+		Label:       Style{Bold: true},
+		Default:     Style{Foreground: Cyan},
+		Hint:        Style{Foreground: BrightBlack},
+		Error:       Style{Foreground: Red, Bold: true},
+		Selected:    Style{Foreground: Cyan, Bold: true},
+		Match:       Style{Bold: true, Underline: true},
+		Summary:     Style{Foreground: Green},
+		Placeholder: Style{Faint: true},
+	}
+	// VividTheme uses RGB colors, approximated on terminals without true color support
+	VividTheme = Theme{
+		Label:       Style{Foreground: RGB(0x5f, 0xaf, 0xff), Bold: true},
+		Default:     Style{Foreground: RGB(0xaf, 0x87, 0xff)},
+		Hint:        Style{Foreground: RGB(0x87, 0x87, 0x87), Italic: true},
+		Error:       Style{Foreground: RGB(0xff, 0x5f, 0x5f), Bold: true},
+		Selected:    Style{Foreground: RGB(0xff, 0xaf, 0x00), Bold: true},
+		Match:       Style{Bold: true, Underline: true},
+		Summary:     Style{Foreground: RGB(0x5f, 0xd7, 0x87)},
+		Placeholder: Style{Foreground: RGB(0x6c, 0x6c, 0x6c)},
+	}
+	// MonochromeTheme uses attributes only, for terminals with a custom palette
+	MonochromeTheme = Theme{
+		Label:       Style{Bold: true},
+		Default:     Style{Underline: true},
+		Hint:        Style{Faint: true},
+		Error:       Style{Bold: true},
+		Selected:    Style{Bold: true, Underline: true},
+		Match:       Style{Underline: true},
+		Summary:     Style{Faint: true},
+		Placeholder: Style{Faint: true, Italic: true},
+	}
+)
+
+// getColorLevel returns the level set with SetOption(WithColorLevel), or the level detected for the output writer;
+// ColorLevelNone in accessible mode
+func (h *Prompt) getColorLevel() ColorLevel {
+	if h.isAccessible() {
+		return ColorLevelNone
+	}
+	if h.colorLevel != ColorLevelAuto {
+		return h.colorLevel
+	}
+	return DetectColorLevel(h.getRawOutputWriter())
+}
+
+// getTheme returns the Theme set with SetOption(WithTheme); Its zero value, which styles nothing, if none is set
+func (h *Prompt) getTheme() Theme {
+	if h.theme != nil {
+		return *h.theme
+	}
+	return Theme{}
+}
+
+// style renders text in s at the color level of the prompt; Plain text if no Theme is set
+func (h *Prompt) style(s Style, text string) string {
+	if h.theme == nil {
+		return text
+	}
+	return s.Render(h.getColorLevel(), text)
+}
@@ -0,0 +1,125 @@
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestColorLevelFromEnv(t *testing.T) {
+	tests := []struct {
+		term      string
+		colorTerm string
+		want      ColorLevel
+	}{
+		{term: "", want: ColorLevelNone},
+		{term: "dumb", colorTerm: "truecolor", want: ColorLevelNone},
+		{term: "xterm", want: ColorLevel16},
+		{term: "xterm-256color", want: ColorLevel256},
+		{term: "xterm-256color", colorTerm: "truecolor", want: ColorLevelTrueColor},
+		{term: "screen", colorTerm: "24bit", want: ColorLevelTrueColor},
+	}
+	for _, tt := range tests {
+		if got := colorLevelFromEnv(tt.term, tt.colorTerm); got != tt.want {
+			t.Errorf("colorLevelFromEnv(%q, %q) = %v, want %v", tt.term, tt.colorTerm, got, tt.want)
+		}
+	}
+}
+
+func TestDetectColorLevel(t *testing.T) {
+	t.Setenv("TERM", "xterm-256color")
+	if got := DetectColorLevel(new(bytes.Buffer)); got != ColorLevelNone {
+		t.Errorf("DetectColorLevel() of a buffer = %v, want %v", got, ColorLevelNone)
+	}
+	t.Setenv("NO_COLOR", "1")
+	if got := DetectColorLevel(defaultOutputWriter); got != ColorLevelNone {
+		t.Errorf("DetectColorLevel() with NO_COLOR = %v, want %v", got, ColorLevelNone)
+	}
+}
+
+func TestColor_sgr(t *testing.T) {
+	tests := []struct {
+		name  string
+		color Color
+		level ColorLevel
+		want  string
+	}{
+		{name: "Unset", color: Color{}, level: ColorLevelTrueColor, want: ""},
+		{name: "No colors", color: Red, level: ColorLevelNone, want: ""},
+		{name: "Basic", color: Red, level: ColorLevelTrueColor, want: "31"},
+		{name: "Bright", color: BrightBlack, level: ColorLevel16, want: "90"},
+		{name: "256", color: ANSI256(208), level: ColorLevel256, want: "38;5;208"},
+		{name: "256 as basic", color: ANSI256(196), level: ColorLevel16, want: "91"},
+		{name: "Gray as basic", color: ANSI256(240), level: ColorLevel16, want: "30"},
+		{name: "RGB", color: RGB(255, 128, 0), level: ColorLevelTrueColor, want: "38;2;255;128;0"},
+		{name: "RGB as 256", color: RGB(255, 0, 0), level: ColorLevel256, want: "38;5;196"},
+		{name: "RGB as basic", color: RGB(0, 160, 0), level: ColorLevel16, want: "32"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.color.sgr(tt.level); got != tt.want {
+				t.Errorf("sgr() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStyle_Render(t *testing.T) {
+	s := Style{Foreground: Green, Bold: true, Underline: true}
+	if got := s.Render(ColorLevel16, "ok"); got != "\x1b[1;4;32mok\x1b[0m" {
+		t.Errorf("Render() = %q, want styled text", got)
+	}
+	if got := s.Render(ColorLevelNone, "ok"); got != "ok" {
+		t.Errorf("Render() = %q, want plain text", got)
+	}
+	if got := (Style{}).Render(ColorLevel16, "ok"); got != "ok" {
+		t.Errorf("Render() of an empty Style = %q, want plain text", got)
+	}
+}
+
+func TestPrompt_Theme(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Opt
+		input    string
+		wantText string
+	}{
+		{name: "Styled", options: []Opt{WithTheme(DefaultTheme), WithColorLevel(ColorLevel16)}, input: "x\n", wantText: "\x1b[1mAge\x1b[0m [\x1b[36m42\x1b[0m]: \n\x1b[1;31mInvalid Input\x1b[0m [x]\n\n"},
+		{name: "Not a terminal", options: []Opt{WithTheme(DefaultTheme)}, input: "x\n", wantText: "Age [42]: \nInvalid Input [x]\n\n"},
+		{name: "Disabled", options: []Opt{WithTheme(DefaultTheme), WithColorLevel(ColorLevelNone)}, input: "x\n", wantText: "Age [42]: \nInvalid Input [x]\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := &Prompt{PromptMessage: "Age", DefaultAsString: "42", InputValidatorFunc: func(s string) bool { return s != "x" }}
+			p.SetOptions(append(tt.options, WithReader(strings.NewReader(tt.input+"\n")), WithWriter(out))...)
+			if _, err := p.Show(); err != nil {
+				t.Fatalf("Show() error = %v", err)
+			}
+			if !strings.HasPrefix(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestSelect_renderSelected(t *testing.T) {
+	s := &Select{}
+	s.SetOptions(WithWriter(new(bytes.Buffer)))
+	m := optionMatch{option: Option{Label: "abc"}, positions: []int{1}}
+	if got := s.renderSelected(m); got != "> abc" {
+		t.Errorf("renderSelected() = %q, want plain text without colors", got)
+	}
+	s.SetOptions(WithColorLevel(ColorLevel16))
+	if got := s.renderSelected(m); got != "> a\x1b[1;4mb\x1b[0mc" {
+		t.Errorf("renderSelected() = %q, want unstyled row", got)
+	}
+	s.SetOptions(WithTheme(Theme{Selected: Style{Foreground: Yellow}, Match: Style{Underline: true}}))
+	if got := s.renderSelected(m); got != "\x1b[33m> a\x1b[4mb\x1b[0m\x1b[33mc\x1b[0m" {
+		t.Errorf("renderSelected() = %q, want styled row", got)
+	}
+	s.SetOptions(WithColorLevel(ColorLevelNone))
+	if got := s.renderSelected(m); got != "> abc" {
+		t.Errorf("renderSelected() = %q, want plain text at ColorLevelNone", got)
+	}
+}
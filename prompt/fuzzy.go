@@ -0,0 +1,96 @@
+package prompt
+
+import (
+	"sort"
+	"unicode"
+)
+
+const (
+	fuzzyMatchScore       = 1 // Per matched rune
+	fuzzyConsecutiveBonus = 4 // Matched rune directly follows the previous match
+	fuzzyBoundaryBonus    = 3 // Matched rune starts a word, ie: the "w" of "eu-west-1" or the "S" of "InstanceSize"
+	fuzzyGapPenalty       = 1 // Per rune skipped between matches
+	fuzzyMaxLeadPenalty   = 3 // Cap of the penalty for runes skipped before the first match
+)
+
+// optionMatch is an option which matched the query, with the rune positions of its label that matched
+type optionMatch struct {
+	option    Option
+	positions []int
+	score     int
+}
+
+// filterOptions returns the options matching query, best matches first. Options matching equally well keep their
+// order. If fuzzy is false options are returned as they are
+func filterOptions(options []Option, query string, fuzzy bool) []optionMatch {
+	var ret []optionMatch
+	for _, o := range options {
+		if !fuzzy {
+			ret = append(ret, optionMatch{option: o})
+			continue
+		}
+		if score, positions, ok := fuzzyMatch(query, o.Label); ok {
+			ret = append(ret, optionMatch{option: o, positions: positions, score: score})
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].score > ret[j].score })
+	return ret
+}
+
+// fuzzyMatch reports if every rune of query appears in label in order, ignoring case. It returns a score ranking
+// better matches higher and the rune positions of label which matched
+func fuzzyMatch(query string, label string) (score int, positions []int, ok bool) {
+	q := toLowerRunes(query)
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+	l := []rune(label)
+	lower := toLowerRunes(label)
+	// Matching greedily from the first occurrence of the first rune may miss a better match, ie: "ws" in
+	// "eu-west-1-web-server", so every start is tried
+	for start := range lower {
+		if lower[start] != q[0] {
+			continue
+		}
+		s, p, matched := fuzzyMatchFrom(q, l, lower, start)
+		if matched && (!ok || s > score) {
+			score, positions, ok = s, p, true
+		}
+	}
+	return score, positions, ok
+}
+
+func fuzzyMatchFrom(q []rune, l []rune, lower []rune, start int) (int, []int, bool) {
+	score := -start * fuzzyGapPenalty
+	if score < -fuzzyMaxLeadPenalty {
+		score = -fuzzyMaxLeadPenalty
+	}
+	positions := make([]int, 0, len(q))
+	prev := -1
+	for i := start; i < len(lower) && len(positions) < len(q); i++ {
+		if lower[i] != q[len(positions)] {
+			continue
+		}
+		score += fuzzyMatchScore
+		if prev >= 0 {
+			if i == prev+1 {
+				score += fuzzyConsecutiveBonus
+			}
+			score -= (i - prev - 1) * fuzzyGapPenalty
+		}
+		if i == 0 || !isWordRune(l[i-1]) || (unicode.IsUpper(l[i]) && unicode.IsLower(l[i-1])) {
+			score += fuzzyBoundaryBonus
+		}
+		positions = append(positions, i)
+		prev = i
+	}
+	return score, positions, len(positions) == len(q)
+}
+
+func toLowerRunes(s string) []rune {
+	r := []rune(s)
+	for i := range r {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return r
+}
@@ -0,0 +1,57 @@
+package prompt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		label         string
+		wantOk        bool
+		wantPositions []int
+	}{
+		{name: "Empty query", query: "", label: "eu-west-1", wantOk: true},
+		{name: "Prefix", query: "eu", label: "eu-west-1", wantOk: true, wantPositions: []int{0, 1}},
+		{name: "Subsequence", query: "ew1", label: "eu-west-1", wantOk: true, wantPositions: []int{0, 3, 8}},
+		{name: "Ignores case", query: "WEST", label: "eu-west-1", wantOk: true, wantPositions: []int{3, 4, 5, 6}},
+		{name: "Prefers word starts", query: "ws", label: "eu-west-1-web-server", wantOk: true, wantPositions: []int{10, 14}},
+		{name: "Prefers consecutive", query: "web", label: "w-e-b web", wantOk: true, wantPositions: []int{6, 7, 8}},
+		{name: "Out of order", query: "1w", label: "eu-west-1", wantOk: false},
+		{name: "Missing rune", query: "eux", label: "eu-west-1", wantOk: false},
+		{name: "Unicode", query: "zü", label: "Zürich", wantOk: true, wantPositions: []int{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, positions, ok := fuzzyMatch(tt.query, tt.label)
+			if ok != tt.wantOk || !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("fuzzyMatch() = %v, %v, want %v, %v", positions, ok, tt.wantPositions, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestFilterOptions(t *testing.T) {
+	options := MakeOptions("us-east-1", "eu-west-2", "eu-west-1", "ap-south-1")
+	labels := func(matches []optionMatch) []string {
+		var ret []string
+		for _, m := range matches {
+			ret = append(ret, m.option.Label)
+		}
+		return ret
+	}
+	if got, want := labels(filterOptions(options, "", true)), []string{"us-east-1", "eu-west-2", "eu-west-1", "ap-south-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filterOptions() = %v, want all options in order %v", got, want)
+	}
+	if got, want := labels(filterOptions(options, "west", true)), []string{"eu-west-2", "eu-west-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filterOptions() = %v, want %v", got, want)
+	}
+	if got, want := labels(filterOptions(options, "es1", true)), []string{"eu-west-1", "us-east-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filterOptions() = %v, want %v", got, want)
+	}
+	if got, want := labels(filterOptions(options, "zzz", false)), labels(filterOptions(options, "", true)); !reflect.DeepEqual(got, want) {
+		t.Errorf("filterOptions() without fuzzy = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package prompt
+
+import (
+	"fmt"
+)
+
+const (
+	helpKeyword  = "?"
+	helpKeyRune  = '?'
+	helpTemplate = "\n%v\n\n"
+)
+
+// defaultPlaceholderStyle is used for Placeholder if no Theme is set
+var defaultPlaceholderStyle = Style{Faint: true}
+
+// isHelpRequest reports if input asks for Help instead of answering
+func (h *Prompt) isHelpRequest(input string) bool {
+	return h.Help != "" && !h.IsPassword && input == helpKeyword
+}
+
+func (h *Prompt) displayHelp() {
+	fmt.Fprintf(h.getOutputWriter(), helpTemplate, h.renderHelp())
+}
+
+// renderHelp returns Help in the Hint style of the Theme
+func (h *Prompt) renderHelp() string {
+	if h.Help == "" {
+		return ""
+	}
+	return h.style(h.getTheme().Hint, h.Help)
+}
+
+// renderPlaceholder returns Placeholder styled as a placeholder and its width. Nothing is returned if the output
+// doesn't display colors, as plain example text can't be told apart from input
+func (h *Prompt) renderPlaceholder() (string, int) {
+	level := h.getColorLevel()
+	if h.Placeholder == "" || level <= ColorLevelNone {
+		return "", 0
+	}
+	style := defaultPlaceholderStyle
+	if h.theme != nil && h.theme.Placeholder != (Style{}) {
+		style = h.theme.Placeholder
+	}
+	return style.Render(level, h.Placeholder), stringWidth(h.Placeholder)
+}
@@ -0,0 +1,89 @@
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrompt_Help(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   Prompt
+		options  []Opt
+		input    string
+		want     interface{}
+		wantText string
+	}{
+		{name: "Keyword", prompt: Prompt{Help: "Your full name"}, input: "?\nBob\n", want: "Bob", wantText: "Name: \nYour full name\n\nName: "},
+		{name: "No help", input: "?\n", want: "?", wantText: "Name: "},
+		{name: "Not invalid", prompt: Prompt{Help: "Letters only", InputValidatorFunc: func(s string) bool { return s != "?" && s != "1" }}, input: "?\n1\nBob\n", want: "Bob", wantText: "Name: \nLetters only\n\nName: \nInvalid Input [1]\n\nName: "},
+		{name: "Line editor", prompt: Prompt{Help: "Your full\nname"}, options: []Opt{WithLineEditor()}, input: "?Bob\r", want: "Bob", wantText: "Name: \r\nYour full\r\nname\r\n\rName: "},
+		{name: "Line editor F1", prompt: Prompt{Help: "Your full name"}, options: []Opt{WithLineEditor()}, input: "Bo\x1bOPb\r", want: "Bob", wantText: "\r\nYour full name\r\n\rName: Bo"},
+		{name: "Line editor question mark", prompt: Prompt{Help: "Your full name"}, options: []Opt{WithLineEditor()}, input: "Bob?\r", want: "Bob?", wantText: "Name: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := tt.prompt
+			p.PromptMessage = "Name"
+			p.SetOptions(append(tt.options, WithReader(strings.NewReader(tt.input)), WithWriter(out))...)
+			got, err := p.Show()
+			if err != nil || got != tt.want {
+				t.Fatalf("Show() = %v, %v, want %v", got, err, tt.want)
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestPrompt_Placeholder(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []Opt
+		input    string
+		want     interface{}
+		wantText string
+		skipText string
+	}{
+		{name: "Shown", options: []Opt{WithColorLevel(ColorLevel16)}, input: "Bob\r", want: "Bob", wantText: "\rName: \x1b[K\x1b[2mJane Doe\x1b[0m\x1b[8D"},
+		{name: "Themed", options: []Opt{WithColorLevel(ColorLevel16), WithTheme(MonochromeTheme)}, input: "Bob\r", want: "Bob", wantText: "\x1b[2;3mJane Doe\x1b[0m\x1b[8D"},
+		{name: "Not a value", options: []Opt{WithColorLevel(ColorLevel16)}, input: "\rBob\r", want: "Bob", wantText: "\x1b[8D\x1b[K\r\n\nInvalid Input [null]"},
+		{name: "No colors", input: "Bob\r", want: "Bob", skipText: "Jane Doe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := &Prompt{PromptMessage: "Name", Placeholder: "Jane Doe"}
+			p.SetOptions(append(tt.options, WithLineEditor(), WithReader(strings.NewReader(tt.input)), WithWriter(out))...)
+			got, err := p.Show()
+			if err != nil || got != tt.want {
+				t.Fatalf("Show() = %v, %v, want %v", got, err, tt.want)
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+			if tt.skipText != "" && strings.Contains(out.String(), tt.skipText) {
+				t.Errorf("Show() output = %q, want no %q", out.String(), tt.skipText)
+			}
+		})
+	}
+}
+
+func TestPromptList_Help(t *testing.T) {
+	out := new(bytes.Buffer)
+	l := MakePromptList(
+		Prompt{PromptMessage: "Name", MapKey: "name", Help: "Your full name"},
+		Prompt{PromptMessage: "City", MapKey: "city", Help: "Where you live"},
+	)
+	l.SetOptions(WithReader(strings.NewReader("?\nBob\n?\nParis\n")), WithWriter(out))
+	got, err := l.Show()
+	if err != nil || got["name"] != "Bob" || got["city"] != "Paris" {
+		t.Fatalf("Show() = %v, %v", got, err)
+	}
+	if !strings.Contains(out.String(), "Your full name") || !strings.Contains(out.String(), "Where you live") {
+		t.Errorf("Show() output = %q, want both help texts", out.String())
+	}
+}
@@ -0,0 +1,157 @@
+package prompt
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	defaultHistorySize = 500
+	historyFileMode    = 0600
+)
+
+// History holds previously entered answers, per prompt, for recall with the up/down arrows and Ctrl-R reverse
+// search of the line editor. Prompts are keyed by HistoryID, or MapKey if not set, and IsPassword, IsMultiLine and
+// IsEditor prompts are never recorded. If created with a file name, every change is persisted to that file
+type History struct {
+	mu         sync.Mutex
+	file       string
+	maxEntries int
+	entries    map[string][]string
+	err        error
+}
+
+// NewHistory returns a History persisted to the named file, loading any entries it already holds. An empty name
+// keeps the history in memory only. Each prompt keeps at most maxEntries entries, the oldest are dropped first;
+// maxEntries <= 0 selects the default of 500
+func NewHistory(name string, maxEntries int) (*History, error) {
+	if maxEntries <= 0 {
+		maxEntries = defaultHistorySize
+	}
+	h := &History{
+		file:       name,
+		maxEntries: maxEntries,
+		entries:    make(map[string][]string),
+	}
+	if name == "" {
+		return h, nil
+	}
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &h.entries); err != nil {
+		return nil, err
+	}
+	for id := range h.entries {
+		h.entries[id] = h.trim(h.entries[id])
+	}
+	return h, nil
+}
+
+// WithHistory returns an option func which enables input history recall and recording for the prompt
+func WithHistory(h *History) Opt {
+	return func(p *Prompt) error {
+		p.history = h
+		return nil
+	}
+}
+
+// Entries returns the history of the given prompt, oldest first
+func (h *History) Entries(id string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.entries[id]...)
+}
+
+// Add appends entry to the history of the given prompt and persists the history. An entry equal to the most recent
+// one is not repeated
+func (h *History) Add(id string, entry string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	entries := h.entries[id]
+	if len(entries) > 0 && entries[len(entries)-1] == entry {
+		return nil
+	}
+	h.entries[id] = h.trim(append(entries, entry))
+	return h.save()
+}
+
+// Err returns the last error encountered while persisting the history
+func (h *History) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+func (h *History) trim(entries []string) []string {
+	if len(entries) > h.maxEntries {
+		return entries[len(entries)-h.maxEntries:]
+	}
+	return entries
+}
+
+func (h *History) save() error {
+	if h.file == "" {
+		return nil
+	}
+	h.err = h.writeFile()
+	return h.err
+}
+
+// writeFile writes the history to a temporary file which then replaces the history file, so it is never left truncated
+func (h *History) writeFile() error {
+	data, err := json.Marshal(h.entries)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(h.file), filepath.Base(h.file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(historyFileMode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), h.file)
+}
+
+func (h *Prompt) getHistoryID() string {
+	if h.HistoryID != "" {
+		return h.HistoryID
+	}
+	return h.MapKey
+}
+
+// hasHistory reports if the prompt's input is recalled and recorded. Only single line input is
+func (h *Prompt) hasHistory() bool {
+	return h.history != nil && !h.IsPassword && !h.IsMultiLine && !h.IsEditor && h.getHistoryID() != ""
+}
+
+func (h *Prompt) getHistoryEntries() []string {
+	if !h.hasHistory() {
+		return nil
+	}
+	return h.history.Entries(h.getHistoryID())
+}
+
+func (h *Prompt) addToHistory(input string) {
+	if !h.hasHistory() {
+		return
+	}
+	// Persistence errors are reported through History.Err; They must not fail the prompt
+	h.history.Add(h.getHistoryID(), input)
+}
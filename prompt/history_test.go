@@ -0,0 +1,163 @@
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewHistory_Persistence(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "history.json")
+	h, err := NewHistory(name, 2)
+	if err != nil {
+		t.Fatalf("NewHistory() error = %v", err)
+	}
+	for _, entry := range []string{"one", "two", "two", "three"} {
+		if err := h.Add("host", entry); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	h.Add("user", "bob")
+
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatalf("history file was not written: %v", err)
+	}
+	if info.Mode().Perm() != historyFileMode {
+		t.Errorf("history file mode = %v, want %v", info.Mode().Perm(), os.FileMode(historyFileMode))
+	}
+
+	reloaded, err := NewHistory(name, 1)
+	if err != nil {
+		t.Fatalf("NewHistory() error = %v", err)
+	}
+	if got, want := reloaded.Entries("host"), []string{"three"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() = %v, want %v", got, want)
+	}
+	if got, want := reloaded.Entries("user"), []string{"bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() = %v, want %v", got, want)
+	}
+	if got := h.Entries("host"); !reflect.DeepEqual(got, []string{"two", "three"}) {
+		t.Errorf("Entries() = %v, want %v", got, []string{"two", "three"})
+	}
+}
+
+func TestNewHistory_Errors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalid, []byte("not json"), 0600)
+	if _, err := NewHistory(invalid, 0); err == nil {
+		t.Errorf("NewHistory() error = nil, want error")
+	}
+	if _, err := NewHistory(dir, 0); err == nil {
+		t.Errorf("NewHistory() on a directory error = nil, want error")
+	}
+
+	h, err := NewHistory(filepath.Join(dir, "missing", "history.json"), 0)
+	if err != nil {
+		t.Fatalf("NewHistory() error = %v", err)
+	}
+	if err := h.Add("host", "one"); err == nil || h.Err() != err {
+		t.Errorf("Add() error = %v, Err() = %v, want unwritable file error", err, h.Err())
+	}
+	if got := h.Entries("host"); !reflect.DeepEqual(got, []string{"one"}) {
+		t.Errorf("Entries() = %v, want entry kept in memory", got)
+	}
+}
+
+func TestLineEditor_History(t *testing.T) {
+	history := []string{"alpha.example.com", "beta.example.com", "gamma.test"}
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "Up recalls newest", input: "\x1b[A\r", want: "gamma.test"},
+		{name: "Up twice", input: "\x1b[A\x1b[A\r", want: "beta.example.com"},
+		{name: "Up past oldest", input: "\x1b[A\x1b[A\x1b[A\x1b[A\r", want: "alpha.example.com"},
+		{name: "Down restores new line", input: "new\x1b[A\x1b[A\x1b[B\x1b[B\r", want: "new"},
+		{name: "Recalled entry is editable", input: "\x1b[A\x7f\x7f\x7f\x7fprod\r", want: "gamma.prod"},
+		{name: "Reverse search", input: "\x12exa\r", want: "beta.example.com"},
+		{name: "Reverse search older match", input: "\x12exa\x12\r", want: "alpha.example.com"},
+		{name: "Reverse search past oldest keeps match", input: "\x12exa\x12\x12\r", want: "alpha.example.com"},
+		{name: "Reverse search backspace", input: "\x12alx\x7f\r", want: "alpha.example.com"},
+		{name: "Failed search keeps line", input: "typed\x12zzz\r", want: "typed"},
+		{name: "Escape accepts match", input: "\x12gam\x1b", want: "gamma.test"},
+		{name: "Movement accepts match for editing", input: "\x12gam\x05\x7f\x7f\x7f\x7fprod\r", want: "gamma.prod"},
+		{name: "Down after search", input: "\x12beta\x05\x1b[B\r", want: "gamma.test"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newLineEditor(bufio.NewReader(strings.NewReader(tt.input)), new(bytes.Buffer))
+			got, err := e.readLine("> ", history)
+			if err != nil {
+				t.Fatalf("readLine() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineEditor_ReverseSearchRefresh(t *testing.T) {
+	out := new(bytes.Buffer)
+	e := newLineEditor(bufio.NewReader(strings.NewReader("\x12ga\x12\r")), out)
+	if _, err := e.readLine("> ", []string{"gamma"}); err != nil {
+		t.Fatalf("readLine() error = %v", err)
+	}
+	for _, want := range []string{"(reverse-i-search)`ga': gamma", "(failed reverse-i-search)`ga': gamma"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("readLine() output = %q, want %q", out.String(), want)
+		}
+	}
+}
+
+func TestPrompt_ReverseSearchLocale(t *testing.T) {
+	h, _ := NewHistory("", 0)
+	h.Add("host", "gamma")
+	out := new(bytes.Buffer)
+	p := &Prompt{PromptMessage: "Host", MapKey: "host"}
+	p.SetOptions(WithHistory(h), WithLocale("de"), WithLineEditor(), WithReader(strings.NewReader("\x12ga\x12\r")), WithWriter(out))
+	if _, err := p.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	for _, want := range []string{"(Rückwärtssuche)`ga': gamma", "(erfolglose Rückwärtssuche)`ga': gamma"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Show() output = %q, want %q", out.String(), want)
+		}
+	}
+}
+
+func TestPrompt_History(t *testing.T) {
+	h, _ := NewHistory("", 0)
+	h.Add("host", "db1")
+
+	p := &Prompt{PromptMessage: "Host", MapKey: "host", InputValidatorFunc: func(s string) bool { return s != "bad" }}
+	p.SetOptions(WithHistory(h), WithLineEditor(), WithReader(strings.NewReader("bad\r\x1b[A2\r")), WithWriter(new(bytes.Buffer)))
+	got, err := p.Show()
+	if err != nil || got != "db12" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "db12")
+	}
+	if got, want := h.Entries("host"), []string{"db1", "db12"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() = %v, want %v (invalid input must not be recorded)", got, want)
+	}
+
+	p = &Prompt{PromptMessage: "Host", MapKey: "host", HistoryID: "shared"}
+	p.SetOptions(WithHistory(h), WithReader(strings.NewReader("web1\n")), WithWriter(new(bytes.Buffer)))
+	p.Show()
+	if got, want := h.Entries("shared"), []string{"web1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Entries() = %v, want %v", got, want)
+	}
+
+	p = &Prompt{PromptMessage: "Password", MapKey: "password", IsPassword: true}
+	p.SetOptions(WithHistory(h), WithReader(strings.NewReader("secret\n")), WithWriter(new(bytes.Buffer)))
+	p.Show()
+	if got := h.Entries("password"); len(got) != 0 {
+		t.Errorf("Entries() = %v, password must not be recorded", got)
+	}
+}
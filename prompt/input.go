@@ -0,0 +1,84 @@
+package prompt
+
+import (
+	"bufio"
+	"io"
+)
+
+// stdinInput is shared by all prompts reading the default os.Stdin
+var stdinInput = newSharedInput(defaultInputReader)
+
+// sharedInput is the buffered input shared by all prompts reading the same io.Reader. Line reads, line editor keys
+// and passwords all come from the one buffer, so no prompt reads ahead into input meant for the next one
+type sharedInput struct {
+	buffered *bufio.Reader
+	source   readAheadReader
+}
+
+// readAheadReader keeps the raw input read into the buffer until it is consumed, so it can be recorded for the
+// prompt which consumed it rather than the prompt whose read filled the buffer
+type readAheadReader struct {
+	r          io.Reader
+	unconsumed []byte
+}
+
+func (r *readAheadReader) Read(b []byte) (int, error) {
+	n, err := r.r.Read(b)
+	r.unconsumed = append(r.unconsumed, b[:n]...)
+	return n, err
+}
+
+func newSharedInput(r io.Reader) *sharedInput {
+	in := &sharedInput{source: readAheadReader{r: r}}
+	in.buffered = bufio.NewReader(&in.source)
+	return in
+}
+
+// consumed returns the raw input consumed from the buffer since the last call to consumed or discardConsumed
+func (in *sharedInput) consumed() string {
+	n := len(in.source.unconsumed) - in.buffered.Buffered()
+	s := string(in.source.unconsumed[:n])
+	in.dropConsumed(n)
+	return s
+}
+
+// discardConsumed drops the raw input consumed from the buffer since the last call, ie: a password, without copying it
+func (in *sharedInput) discardConsumed() {
+	in.dropConsumed(len(in.source.unconsumed) - in.buffered.Buffered())
+}
+
+// dropConsumed removes the first n bytes of the read-ahead and wipes the bytes freed at its end
+func (in *sharedInput) dropConsumed(n int) {
+	rest := copy(in.source.unconsumed, in.source.unconsumed[n:])
+	wipe(in.source.unconsumed[rest:])
+	in.source.unconsumed = in.source.unconsumed[:rest]
+}
+
+// getInput returns the buffered input of the prompt
+func (h *Prompt) getInput() *bufio.Reader {
+	if h.input == nil {
+		if h.inputReader == nil {
+			h.input = stdinInput
+		} else {
+			h.input = newSharedInput(h.inputReader)
+		}
+	}
+	return h.input.buffered
+}
+
+// recordInput records the raw input the prompt consumed since it was last called, attributing it to the prompt which
+// consumed it. Key based input, ie: the line editor and Select, calls it after every key so the recording keeps the
+// timing of the keystrokes; Line input records every line. Input of password prompts is discarded; It is recorded,
+// redacted, once the password has been read
+func (h *Prompt) recordInput() {
+	if h.input == nil {
+		return
+	}
+	if h.IsPassword {
+		h.input.discardConsumed()
+		return
+	}
+	if data := h.input.consumed(); data != "" {
+		h.recorder.record(EventInput, h.MapKey, data, false)
+	}
+}
@@ -0,0 +1,154 @@
+package prompt
+
+import (
+	"bufio"
+)
+
+// keyCode identifies a key press decoded from terminal input
+type keyCode int
+
+const (
+	keyRune keyCode = iota // A printable rune, held in key.r
+	keyEnter
+	keyTab
+	keyBackspace
+	keyDelete
+	keyLeft
+	keyRight
+	keyUp
+	keyDown
+	keyHome
+	keyEnd
+	keyPageUp
+	keyPageDown
+	keyWordLeft
+	keyWordRight
+	keyKillToEnd
+	keyKillToStart
+	keyKillWordLeft
+	keyKillWordRight
+	keyKillSpaceLeft
+	keyYank
+	keyYankPop
+	keyReverseSearch
+	keyInterrupt
+	keyEOF // Ctrl-D
+	keyEscape
+	keyHelp // F1
+	keyUnknown
+)
+
+type key struct {
+	code keyCode
+	r    rune
+}
+
+var controlKeys = map[rune]keyCode{
+	0x01: keyHome,          // Ctrl-A
+	0x02: keyLeft,          // Ctrl-B
+	0x03: keyInterrupt,     // Ctrl-C
+	0x04: keyEOF,           // Ctrl-D
+	0x05: keyEnd,           // Ctrl-E
+	0x06: keyRight,         // Ctrl-F
+	0x08: keyBackspace,     // Ctrl-H
+	0x09: keyTab,           // Ctrl-I
+	0x0a: keyEnter,         // Ctrl-J
+	0x0b: keyKillToEnd,     // Ctrl-K
+	0x0d: keyEnter,         // Ctrl-M
+	0x0e: keyDown,          // Ctrl-N
+	0x10: keyUp,            // Ctrl-P
+	0x12: keyReverseSearch, // Ctrl-R
+	0x15: keyKillToStart,   // Ctrl-U
+	0x17: keyKillSpaceLeft, // Ctrl-W
+	0x19: keyYank,          // Ctrl-Y
+	0x7f: keyBackspace,     // DEL
+}
+
+// CSI / SS3 final bytes; Also used by Home/End/Delete sequences of the form ESC [ n ~
+var escapeSequenceKeys = map[string]keyCode{
+	"A":    keyUp,
+	"B":    keyDown,
+	"C":    keyRight,
+	"D":    keyLeft,
+	"H":    keyHome,
+	"F":    keyEnd,
+	"1~":   keyHome,
+	"7~":   keyHome,
+	"4~":   keyEnd,
+	"8~":   keyEnd,
+	"3~":   keyDelete,
+	"5~":   keyPageUp,
+	"6~":   keyPageDown,
+	"1;5C": keyWordRight,
+	"1;5D": keyWordLeft,
+	"1;3C": keyWordRight,
+	"1;3D": keyWordLeft,
+	"P":    keyHelp,
+	"11~":  keyHelp,
+}
+
+var altKeys = map[rune]keyCode{
+	'b':  keyWordLeft,
+	'f':  keyWordRight,
+	'd':  keyKillWordRight,
+	'y':  keyYankPop,
+	0x7f: keyKillWordLeft, // Alt-Backspace
+}
+
+// readKey decodes a single key press from r
+func readKey(r *bufio.Reader) (key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+	if c == 0x1b {
+		return readEscape(r)
+	}
+	if c == '\r' && r.Buffered() > 0 {
+		// CRLF is a single Enter
+		if next, _ := r.Peek(1); next[0] == '\n' {
+			r.ReadByte()
+		}
+	}
+	if code, ok := controlKeys[c]; ok {
+		return key{code: code}, nil
+	}
+	if c < 0x20 {
+		return key{code: keyUnknown}, nil
+	}
+	return key{code: keyRune, r: c}, nil
+}
+
+// readEscape decodes the remainder of an escape sequence. A lone ESC is only reported once no further input is
+// buffered, since terminals send a complete sequence in a single write
+func readEscape(r *bufio.Reader) (key, error) {
+	if r.Buffered() == 0 {
+		return key{code: keyEscape}, nil
+	}
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+	if c != '[' && c != 'O' {
+		if code, ok := altKeys[c]; ok {
+			return key{code: code}, nil
+		}
+		return key{code: keyUnknown}, nil
+	}
+	var seq []rune
+	for r.Buffered() > 0 {
+		c, _, err := r.ReadRune()
+		if err != nil {
+			return key{}, err
+		}
+		seq = append(seq, c)
+		// Parameters and intermediates are in 0x20-0x3f, the final byte ends the sequence
+		if c >= 0x40 && c <= 0x7e {
+			break
+		}
+	}
+	if code, ok := escapeSequenceKeys[string(seq)]; ok {
+		return key{code: code}, nil
+	}
+	return key{code: keyUnknown}, nil
+}
@@ -0,0 +1,82 @@
+package prompt
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readAllKeys(t *testing.T, input string) []key {
+	r := bufio.NewReader(strings.NewReader(input))
+	var keys []key
+	for {
+		k, err := readKey(r)
+		if err != nil {
+			return keys
+		}
+		keys = append(keys, k)
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []key
+	}{
+		{
+			name:  "Runes",
+			input: "hé",
+			want:  []key{{code: keyRune, r: 'h'}, {code: keyRune, r: 'é'}},
+		},
+		{
+			name:  "Control keys",
+			input: "\x01\x03\x04\x05\x0b\x15\x17\x19\x7f\t",
+			want: []key{{code: keyHome}, {code: keyInterrupt}, {code: keyEOF}, {code: keyEnd}, {code: keyKillToEnd},
+				{code: keyKillToStart}, {code: keyKillSpaceLeft}, {code: keyYank}, {code: keyBackspace}, {code: keyTab}},
+		},
+		{
+			name:  "F1",
+			input: "\x1bOP\x1b[11~",
+			want:  []key{{code: keyHelp}, {code: keyHelp}},
+		},
+		{
+			name:  "Enter variants",
+			input: "\r\n\r\n",
+			want:  []key{{code: keyEnter}, {code: keyEnter}},
+		},
+		{
+			name:  "Arrows",
+			input: "\x1b[A\x1b[B\x1b[C\x1b[D\x1bOH\x1bOF",
+			want:  []key{{code: keyUp}, {code: keyDown}, {code: keyRight}, {code: keyLeft}, {code: keyHome}, {code: keyEnd}},
+		},
+		{
+			name:  "Tilde sequences",
+			input: "\x1b[1~\x1b[4~\x1b[3~",
+			want:  []key{{code: keyHome}, {code: keyEnd}, {code: keyDelete}},
+		},
+		{
+			name:  "Word movement",
+			input: "\x1bb\x1bf\x1b[1;5D\x1b[1;5C",
+			want:  []key{{code: keyWordLeft}, {code: keyWordRight}, {code: keyWordLeft}, {code: keyWordRight}},
+		},
+		{
+			name:  "Alt keys",
+			input: "\x1bd\x1by\x1b\x7f\x1bz",
+			want:  []key{{code: keyKillWordRight}, {code: keyYankPop}, {code: keyKillWordLeft}, {code: keyUnknown}},
+		},
+		{
+			name:  "Unknown sequence and lone escape",
+			input: "\x1b[99Zx\x1b",
+			want:  []key{{code: keyUnknown}, {code: keyRune, r: 'x'}, {code: keyEscape}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readAllKeys(t, tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,605 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/completion"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"io"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	ansiClearToEnd       = "\x1b[K"
+	ansiCursorBackTpl    = "\x1b[%dD"
+	ansiCursorForwardTpl = "\x1b[%dC"
+	ansiCursorDownTpl    = "\x1b[%dB"
+	killRingSize         = 16
+	searchNoMatch        = -1
+	bell                 = "\a"
+	completionSeparator  = "  "
+)
+
+// lineEditor reads a line of input with emacs style editing: Ctrl-A/E, Ctrl-B/F, word movement with Alt-B/F or
+// Ctrl-Left/Right, Home/End, Delete, kill/yank through a kill ring with Ctrl-K/U/W, Alt-D and Ctrl-Y / Alt-Y, and
+// history recall with Up/Down and Ctrl-R reverse search, and Tab completion
+type lineEditor struct {
+	in     *bufio.Reader
+	out    io.Writer
+	prompt string // The last line of the rendered prompt; Redrawn in front of the input on every change
+	buf    []rune
+	pos    int
+
+	killRing  [][]rune
+	lastKill  bool // The previous key killed text, so the next kill is merged into the same kill ring entry
+	lastYank  bool // The previous key yanked text, so Alt-Y may replace it with an older kill ring entry
+	yankIndex int
+	yankStart int
+
+	history      []string       // Entries recalled with Up/Down and Ctrl-R, oldest first
+	historyIndex int            // Index of the history entry being edited; len(history) is the new line
+	newLine      []rune         // The new line, kept while browsing the history
+	search       *historySearch // Set while in Ctrl-R reverse search
+	localizer    i18n.Localizer // Translates the reverse search label; The locale of the i18n package if unset
+
+	completer  completion.Completer
+	lastTab    bool             // The previous key was Tab, so the next Tab cycles through the candidates
+	completion *completionState // Candidates of the previous Tab
+
+	status    func(line string) string // If set, called on every change; Its result is displayed on the line below the input
+	hasStatus bool                     // A status is displayed below the input
+
+	help             string // If set, displayed above the input when F1, or '?' on an empty line, is pressed
+	placeholder      string // If set, displayed after the prompt while the input is empty
+	placeholderWidth int    // Columns taken by placeholder, excluding escape sequences
+
+	keyRead func() // If set, called after every key is read, ie: to record the key as it is typed
+
+	width     func() int // If set, returns the terminal width so long lines are redrawn across rows; 0 if unknown
+	cursorRow int        // Row of the cursor below the first row of the prompt, while the line is wrapped
+
+	mu      sync.Mutex // Held while the line is changed or drawn; Log output is written above the line by a LogWriter holding it
+	reading bool       // Set while readLine waits for keys, so the line may be redrawn
+}
+
+// completionState holds the candidates offered by the previous Tab for cycling
+type completionState struct {
+	candidates []string
+	start      int // Rune offset the candidates are inserted at
+	index      int // Candidate currently inserted, or -1 before cycling started
+}
+
+// historySearch is the state of an incremental reverse search through the history
+type historySearch struct {
+	query  []rune
+	match  int // Index of the matching history entry, or searchNoMatch
+	failed bool
+}
+
+func newLineEditor(in *bufio.Reader, out io.Writer) *lineEditor {
+	return &lineEditor{in: in, out: out}
+}
+
+// readLine reads keys until Enter is pressed and returns the edited line. Ctrl-C returns ErrInterrupted and
+// Ctrl-D on an empty line returns io.EOF
+func (e *lineEditor) readLine(prompt string, history []string) (string, error) {
+	e.mu.Lock()
+	e.prompt = prompt
+	e.buf = e.buf[:0]
+	e.pos = 0
+	e.history = history
+	e.historyIndex = len(history)
+	e.search = nil
+	e.cursorRow = 0
+	e.reading = true
+	if e.placeholder != "" {
+		e.refresh()
+	}
+	e.mu.Unlock()
+	for {
+		k, err := readKey(e.in)
+		if e.keyRead != nil {
+			e.keyRead()
+		}
+		if line, done, err := e.handleRead(k, err); done {
+			return line, err
+		}
+	}
+}
+
+// handleRead handles the result of reading a key with mu held, so the line isn't redrawn by a LogWriter meanwhile.
+// done is set once the line is complete or reading failed
+func (e *lineEditor) handleRead(k key, err error) (string, bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err == io.EOF && len(e.buf) > 0 {
+		// Unterminated last line, as accepted by bufio.Scanner
+		e.reading = false
+		return string(e.buf), true, nil
+	}
+	if err != nil {
+		e.finishLine()
+		e.reading = false
+		return "", true, err
+	}
+	done, err := e.handleKey(k)
+	if err != nil {
+		e.reading = false
+		return "", true, err
+	}
+	if done {
+		e.finishLine()
+		fmt.Fprint(e.out, "\r\n")
+		e.reading = false
+		return string(e.buf), true, nil
+	}
+	return "", false, nil
+}
+
+func (e *lineEditor) handleKey(k key) (bool, error) {
+	wasKill, wasYank, wasTab := e.lastKill, e.lastYank, e.lastTab
+	e.lastKill, e.lastYank, e.lastTab = false, false, false
+
+	if e.search != nil && e.handleSearchKey(k) {
+		return false, nil
+	}
+
+	switch k.code {
+	case keyEnter:
+		return true, nil
+	case keyInterrupt:
+		e.finishLine()
+		fmt.Fprint(e.out, "^C\r\n")
+		return false, ErrInterrupted
+	case keyEOF:
+		if len(e.buf) == 0 {
+			e.finishLine()
+			fmt.Fprint(e.out, "\r\n")
+			return false, io.EOF
+		}
+		e.deleteRange(e.pos, e.pos+1)
+	case keyRune:
+		if k.r == helpKeyRune && len(e.buf) == 0 && e.help != "" {
+			e.showHelp()
+			break
+		}
+		e.insert([]rune{k.r})
+	case keyHelp:
+		e.showHelp()
+	case keyBackspace:
+		e.deleteRange(e.pos-1, e.pos)
+	case keyDelete:
+		e.deleteRange(e.pos, e.pos+1)
+	case keyLeft:
+		e.moveTo(e.pos - 1)
+	case keyRight:
+		e.moveTo(e.pos + 1)
+	case keyHome:
+		e.moveTo(0)
+	case keyEnd:
+		e.moveTo(len(e.buf))
+	case keyWordLeft:
+		e.moveTo(e.wordStart(e.pos))
+	case keyWordRight:
+		e.moveTo(e.wordEnd(e.pos))
+	case keyKillToEnd:
+		e.kill(e.pos, len(e.buf), wasKill)
+	case keyKillToStart:
+		e.kill(0, e.pos, wasKill)
+	case keyKillWordLeft:
+		e.kill(e.wordStart(e.pos), e.pos, wasKill)
+	case keyKillWordRight:
+		e.kill(e.pos, e.wordEnd(e.pos), wasKill)
+	case keyKillSpaceLeft:
+		e.kill(e.spaceStart(e.pos), e.pos, wasKill)
+	case keyYank:
+		e.yank(len(e.killRing) - 1)
+	case keyYankPop:
+		if wasYank && len(e.killRing) > 0 {
+			e.deleteRange(e.yankStart, e.pos)
+			e.yank((e.yankIndex + len(e.killRing) - 1) % len(e.killRing))
+		}
+	case keyTab:
+		e.complete(wasTab)
+		e.lastTab = true
+	case keyUp:
+		e.recall(e.historyIndex - 1)
+	case keyDown:
+		e.recall(e.historyIndex + 1)
+	case keyReverseSearch:
+		if len(e.history) > 0 {
+			e.search = &historySearch{match: searchNoMatch}
+			e.refresh()
+		}
+	}
+	return false, nil
+}
+
+// complete inserts the only candidate, or the longest prefix common to all candidates. If that doesn't extend the
+// input the candidates are listed below the prompt, and further Tabs cycle through them
+func (e *lineEditor) complete(wasTab bool) {
+	if e.completer == nil {
+		return
+	}
+	if wasTab && e.completion != nil {
+		c := e.completion
+		c.index = (c.index + 1) % len(c.candidates)
+		e.replace(c.start, e.pos, c.candidates[c.index])
+		return
+	}
+	e.completion = nil
+	candidates, start := e.completer.Complete(string(e.buf), e.pos)
+	if start < 0 || start > e.pos {
+		start = e.pos
+	}
+	switch len(candidates) {
+	case 0:
+		fmt.Fprint(e.out, bell)
+	case 1:
+		e.replace(start, e.pos, candidates[0])
+	default:
+		typed := string(e.buf[start:e.pos])
+		if prefix := commonPrefix(candidates); len(prefix) > len(typed) && strings.HasPrefix(prefix, typed) {
+			e.replace(start, e.pos, prefix)
+		} else {
+			e.finishLine()
+			fmt.Fprint(e.out, "\r\n"+strings.Join(candidates, completionSeparator)+"\r\n")
+			e.refresh()
+		}
+		e.completion = &completionState{candidates: candidates, start: start, index: -1}
+	}
+}
+
+// showHelp displays help on the lines below the input and redraws the prompt after it
+func (e *lineEditor) showHelp() {
+	if e.help == "" {
+		return
+	}
+	e.finishLine()
+	fmt.Fprint(e.out, "\r\n"+strings.ReplaceAll(e.help, "\n", "\r\n")+"\r\n")
+	e.refresh()
+}
+
+// replace replaces buf[start:end] with text and places the cursor after it
+func (e *lineEditor) replace(start int, end int, text string) {
+	tail := append([]rune(nil), e.buf[end:]...)
+	e.buf = append(append(e.buf[:start], []rune(text)...), tail...)
+	e.pos = start + len([]rune(text))
+	e.refresh()
+}
+
+// recall replaces the line with the history entry at index; The new line being typed is kept while browsing
+func (e *lineEditor) recall(index int) {
+	if index < 0 || index > len(e.history) || index == e.historyIndex {
+		return
+	}
+	if e.historyIndex == len(e.history) {
+		e.newLine = append(e.newLine[:0], e.buf...)
+	}
+	e.historyIndex = index
+	if index == len(e.history) {
+		e.buf = append(e.buf[:0], e.newLine...)
+	} else {
+		e.buf = append(e.buf[:0], []rune(e.history[index])...)
+	}
+	e.pos = len(e.buf)
+	e.refresh()
+}
+
+// handleSearchKey handles a key while in reverse search and reports if it was consumed. Any key which isn't part of
+// the search accepts the match and is then handled as usual, so Enter submits the match
+func (e *lineEditor) handleSearchKey(k key) bool {
+	switch k.code {
+	case keyRune:
+		e.search.query = append(e.search.query, k.r)
+		if e.search.match == searchNoMatch {
+			e.searchFrom(len(e.history) - 1)
+		} else {
+			e.searchFrom(e.search.match)
+		}
+		return true
+	case keyBackspace:
+		if len(e.search.query) > 0 {
+			e.search.query = e.search.query[:len(e.search.query)-1]
+		}
+		e.searchFrom(len(e.history) - 1)
+		return true
+	case keyReverseSearch:
+		if e.search.match == searchNoMatch {
+			e.searchFrom(len(e.history) - 1)
+		} else {
+			e.searchFrom(e.search.match - 1)
+		}
+		return true
+	case keyEscape:
+		e.acceptSearch()
+		return true
+	}
+	e.acceptSearch()
+	return false
+}
+
+// searchFrom finds the newest history entry at or before index containing the query. If there is none the search
+// is marked as failed and the previous match is kept
+func (e *lineEditor) searchFrom(index int) {
+	query := string(e.search.query)
+	e.search.failed = true
+	for i := index; i >= 0; i-- {
+		if strings.Contains(e.history[i], query) {
+			e.search.match = i
+			e.search.failed = false
+			break
+		}
+	}
+	e.refresh()
+}
+
+func (e *lineEditor) acceptSearch() {
+	match := e.search.match
+	e.search = nil
+	if match == searchNoMatch {
+		e.refresh()
+		return
+	}
+	e.recall(match)
+	e.refresh()
+}
+
+func (e *lineEditor) insert(r []rune) {
+	tail := append([]rune(nil), e.buf[e.pos:]...)
+	e.buf = append(append(e.buf[:e.pos], r...), tail...)
+	e.pos += len(r)
+	e.refresh()
+}
+
+func (e *lineEditor) deleteRange(start int, end int) {
+	if start < 0 || end > len(e.buf) || start >= end {
+		return
+	}
+	e.buf = append(e.buf[:start], e.buf[end:]...)
+	e.pos = start
+	e.refresh()
+}
+
+func (e *lineEditor) moveTo(pos int) {
+	if pos < 0 || pos > len(e.buf) || pos == e.pos {
+		return
+	}
+	e.pos = pos
+	e.refresh()
+}
+
+// kill removes buf[start:end] into the kill ring; Consecutive kills are merged into a single entry
+func (e *lineEditor) kill(start int, end int, merge bool) {
+	if start >= end {
+		e.lastKill = merge
+		return
+	}
+	killed := append([]rune(nil), e.buf[start:end]...)
+	if merge && len(e.killRing) > 0 {
+		last := e.killRing[len(e.killRing)-1]
+		if start < e.pos {
+			killed = append(killed, last...)
+		} else {
+			killed = append(append([]rune(nil), last...), killed...)
+		}
+		e.killRing[len(e.killRing)-1] = killed
+	} else {
+		e.killRing = append(e.killRing, killed)
+		if len(e.killRing) > killRingSize {
+			e.killRing = e.killRing[1:]
+		}
+	}
+	e.lastKill = true
+	e.deleteRange(start, end)
+}
+
+func (e *lineEditor) yank(index int) {
+	if index < 0 || index >= len(e.killRing) {
+		return
+	}
+	e.yankIndex = index
+	e.yankStart = e.pos
+	e.insert(e.killRing[index])
+	e.lastYank = true
+}
+
+// wordStart returns the start of the word before pos, skipping any non word characters first
+func (e *lineEditor) wordStart(pos int) int {
+	for pos > 0 && !isWordRune(e.buf[pos-1]) {
+		pos--
+	}
+	for pos > 0 && isWordRune(e.buf[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordEnd returns the end of the word after pos, skipping any non word characters first
+func (e *lineEditor) wordEnd(pos int) int {
+	for pos < len(e.buf) && !isWordRune(e.buf[pos]) {
+		pos++
+	}
+	for pos < len(e.buf) && isWordRune(e.buf[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// spaceStart returns the start of the whitespace delimited word before pos, as used by Ctrl-W
+func (e *lineEditor) spaceStart(pos int) int {
+	for pos > 0 && unicode.IsSpace(e.buf[pos-1]) {
+		pos--
+	}
+	for pos > 0 && !unicode.IsSpace(e.buf[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// refresh redraws the prompt line and places the cursor at pos
+func (e *lineEditor) refresh() {
+	if e.search != nil {
+		e.refreshSearch()
+		return
+	}
+	if cols := e.getWidth(); cols > 0 {
+		e.refreshWrapped(cols)
+		return
+	}
+	fmt.Fprintf(e.out, "\r%v%v%v", e.prompt, string(e.buf), ansiClearToEnd)
+	if n := runesWidth(e.buf[e.pos:]); n > 0 {
+		fmt.Fprintf(e.out, ansiCursorBackTpl, n)
+	}
+	if len(e.buf) == 0 && e.placeholder != "" {
+		fmt.Fprintf(e.out, "%v"+ansiCursorBackTpl, e.placeholder, e.placeholderWidth)
+	}
+	if e.status != nil {
+		reserveLineBelow(e.out)
+		drawBelow(e.out, e.status(string(e.buf)))
+		e.hasStatus = true
+	}
+}
+
+// refreshWrapped redraws the prompt line across as many rows of the cols wide terminal as it takes, and places the
+// cursor at pos
+func (e *lineEditor) refreshWrapped(cols int) {
+	text := e.prompt + string(e.buf)
+	if len(e.buf) == 0 {
+		text += e.placeholder
+	}
+	var b strings.Builder
+	b.WriteString(e.upToPrompt() + "\r" + text + ansiClearScreenDown)
+	endRow, endCol := cursorPosition(text, cols)
+	if endRow > 0 && endCol == 0 {
+		// The text ends at the last column; Terminals only wrap on the next character, so wrap explicitly
+		b.WriteString("\r\n")
+	}
+	fmt.Fprint(e.out, b.String())
+	if e.status != nil {
+		// Drawn below the last row, cut to a single row so it doesn't scroll the input
+		status, rest := splitAtWidth(e.status(string(e.buf)), cols-1)
+		if rest != "" {
+			status += ansiReset
+		}
+		reserveLineBelow(e.out)
+		drawBelow(e.out, status)
+		e.hasStatus = true
+	}
+	row, col := cursorPosition(e.prompt+string(e.buf[:e.pos]), cols)
+	fmt.Fprint(e.out, moveCursor(endRow, row, col))
+	e.cursorRow = row
+}
+
+// upToPrompt returns the escape sequence moving the cursor up to the first row of the prompt
+func (e *lineEditor) upToPrompt() string {
+	if e.cursorRow > 0 {
+		return fmt.Sprintf(ansiCursorUpTpl, e.cursorRow)
+	}
+	return ""
+}
+
+// moveCursor returns the escape sequences moving the cursor from row to the column col of toRow
+func moveCursor(row int, toRow int, col int) string {
+	var b strings.Builder
+	switch {
+	case toRow < row:
+		fmt.Fprintf(&b, ansiCursorUpTpl, row-toRow)
+	case toRow > row:
+		fmt.Fprintf(&b, ansiCursorDownTpl, toRow-row)
+	}
+	b.WriteString("\r")
+	if col > 0 {
+		fmt.Fprintf(&b, ansiCursorForwardTpl, col)
+	}
+	return b.String()
+}
+
+func (e *lineEditor) getWidth() int {
+	if e.width == nil {
+		return 0
+	}
+	return e.width()
+}
+
+// finishLine erases the placeholder and the status before the cursor leaves the line. A wrapped line is left from
+// the end of the input, so output following it doesn't overwrite its last rows
+func (e *lineEditor) finishLine() {
+	if cols := e.getWidth(); cols > 0 {
+		row, col := cursorPosition(e.prompt+string(e.buf), cols)
+		fmt.Fprint(e.out, moveCursor(e.cursorRow, row, col)+ansiClearScreenDown)
+		e.cursorRow = 0
+		e.hasStatus = false
+		return
+	}
+	if len(e.buf) == 0 && e.placeholder != "" {
+		fmt.Fprint(e.out, ansiClearToEnd)
+	}
+	e.clearStatus()
+}
+
+// clearLine erases the prompt line and the status below it, so other output can be written in their place; Does
+// nothing unless readLine is waiting for keys. Must be called with mu held
+func (e *lineEditor) clearLine() {
+	if !e.reading {
+		return
+	}
+	fmt.Fprint(e.out, e.upToPrompt()+"\r"+ansiClearScreenDown)
+	e.cursorRow = 0
+	e.hasStatus = false
+}
+
+// redraw draws the prompt line again after clearLine; Must be called with mu held
+func (e *lineEditor) redraw() {
+	if e.reading {
+		e.refresh()
+	}
+}
+
+// clearStatus erases the status below the input before the cursor leaves the line
+func (e *lineEditor) clearStatus() {
+	if e.hasStatus {
+		drawBelow(e.out, "")
+		e.hasStatus = false
+	}
+}
+
+func (e *lineEditor) refreshSearch() {
+	label := e.localizer.Message(i18n.ReverseSearch, string(e.search.query))
+	if e.search.failed {
+		label = e.localizer.Message(i18n.FailedReverseSearch, string(e.search.query))
+	}
+	match := ""
+	if e.search.match != searchNoMatch {
+		match = e.history[e.search.match]
+	}
+	if cols := e.getWidth(); cols > 0 {
+		text := label + match
+		fmt.Fprint(e.out, e.upToPrompt()+"\r"+text+ansiClearScreenDown)
+		row, col := cursorPosition(text, cols)
+		if row > 0 && col == 0 {
+			fmt.Fprint(e.out, "\r\n")
+		}
+		e.cursorRow = row
+		return
+	}
+	fmt.Fprint(e.out, "\r"+label+match+ansiClearToEnd)
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
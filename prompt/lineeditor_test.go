@@ -0,0 +1,176 @@
+package prompt
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/bchivari/go-cli-prompt/completion"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLineEditor_ReadLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "Plain", input: "Bob\r", want: "Bob"},
+		{name: "Unterminated", input: "Bob", want: "Bob"},
+		{name: "Backspace", input: "Bobb\x7f\r", want: "Bob"},
+		{name: "Insert after cursor movement", input: "Bb\x1b[Do\r", want: "Bob"},
+		{name: "Home and End", input: "ob\x01B\x05!\r", want: "Bob!"},
+		{name: "Delete", input: "Boxb\x1b[D\x1b[D\x1b[3~\r", want: "Bob"},
+		{name: "Ctrl-D deletes under cursor", input: "Boxb\x1b[D\x1b[D\x04\r", want: "Bob"},
+		{name: "Word movement", input: "one two\x1bb\x1bbX\x1bfY\x1b[1;5CZ\r", want: "XoneY twoZ"},
+		{name: "Kill to end and yank", input: "hello world\x01\x1bf\x0b\x01\x19\r", want: " worldhello"},
+		{name: "Kill to start", input: "hello world\x1bb\x15\r", want: "world"},
+		{name: "Ctrl-W kills whitespace word", input: "http://host/path x\x17\x17\r", want: ""},
+		{name: "Alt-Backspace kills word", input: "http://host/path\x1b\x7f\r", want: "http://host/"},
+		{name: "Alt-D kills word forward", input: "one two\x01\x1bd\r", want: " two"},
+		{name: "Consecutive kills merge", input: "one two\x17\x17\x19\r", want: "one two"},
+		{name: "Yank pop", input: "b\x17a\x17\x19\x1by\r", want: "b"},
+		{name: "Movement past bounds is ignored", input: "\x1b[D\x1b[Cab\x1b[C\x7f\x7f\x7f\r", want: ""},
+		{name: "Ctrl-C", input: "Bob\x03", wantErr: ErrInterrupted},
+		{name: "Ctrl-D on empty line", input: "\x04", wantErr: io.EOF},
+		{name: "EOF", input: "", wantErr: io.EOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newLineEditor(bufio.NewReader(strings.NewReader(tt.input)), new(bytes.Buffer))
+			got, err := e.readLine("> ", nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("readLine() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("readLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineEditor_Refresh(t *testing.T) {
+	out := new(bytes.Buffer)
+	e := newLineEditor(bufio.NewReader(strings.NewReader("ab\x1b[D\r")), out)
+	if _, err := e.readLine("Name: ", nil); err != nil {
+		t.Fatalf("readLine() error = %v", err)
+	}
+	want := "\rName: a\x1b[K" + "\rName: ab\x1b[K" + "\rName: ab\x1b[K\x1b[1D" + "\r\n"
+	if out.String() != want {
+		t.Errorf("readLine() output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestLineEditor_RefreshWrapped(t *testing.T) {
+	out := new(bytes.Buffer)
+	e := newLineEditor(bufio.NewReader(strings.NewReader("ab\x1b[D\x1b[D\r")), out)
+	e.width = func() int { return 8 }
+	if _, err := e.readLine("Name: ", nil); err != nil {
+		t.Fatalf("readLine() error = %v", err)
+	}
+	want := "\rName: a\x1b[J\r\x1b[7C" +
+		"\rName: ab\x1b[J\r\n\r" + // Ends at the last column, so the wrap is forced
+		"\x1b[1A\rName: ab\x1b[J\r\n\x1b[1A\r\x1b[7C" +
+		"\rName: ab\x1b[J\r\n\x1b[1A\r\x1b[6C" +
+		"\x1b[1B\r\x1b[J" + "\r\n"
+	if out.String() != want {
+		t.Errorf("readLine() output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestPrompt_LineEditor(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		suppressTrim bool
+		want         interface{}
+		wantErr      error
+	}{
+		{name: "Edited and trimmed", input: " Bb\x1b[Do\x05 \r", want: "Bob"},
+		{name: "Edited, not trimmed", input: " Bb\x1b[Do\x05 \r", suppressTrim: true, want: " Bob "},
+		{name: "Interrupted", input: "Bob\x03", wantErr: ErrInterrupted},
+		{name: "Re-prompt keeps reading keys", input: "\rBob\r", want: "Bob"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Prompt{PromptMessage: "Name", SuppressTrimWhitespace: tt.suppressTrim}
+			p.SetOptions(WithReader(strings.NewReader(tt.input)), WithWriter(new(bytes.Buffer)), WithLineEditor())
+			got, err := p.Show()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Show() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Show() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	p := &Prompt{PromptMessage: "Name", SuppressLineEditor: true}
+	p.SetOptions(WithReader(strings.NewReader("Bb\x1b[Do\n")), WithWriter(new(bytes.Buffer)), WithLineEditor())
+	if got, _ := p.Show(); got != "Bb\x1b[Do" {
+		t.Errorf("Show() with SuppressLineEditor = %q, want raw line", got)
+	}
+}
+
+func TestLineEditor_TabCompletion(t *testing.T) {
+	branches := completion.MakeListCompleter("main", "master", "develop")
+	tests := []struct {
+		name       string
+		completer  completion.Completer
+		input      string
+		want       string
+		wantOutput string
+	}{
+		{name: "Single candidate", completer: branches, input: "d\t\r", want: "develop"},
+		{name: "Common prefix", completer: branches, input: "m\t\r", want: "ma"},
+		{name: "Ambiguous lists candidates", completer: branches, input: "ma\t\r", want: "ma", wantOutput: "\r\nmain  master\r\n"},
+		{name: "Tab cycles candidates", completer: branches, input: "ma\t\t\t\r", want: "master"},
+		{name: "Cycling wraps", completer: branches, input: "ma\t\t\t\t\r", want: "main"},
+		{name: "Other key ends cycling", completer: branches, input: "ma\t\t!\t\r", want: "main!"},
+		{name: "No candidates rings bell", completer: branches, input: "x\t\r", want: "x", wantOutput: bell},
+		{name: "No completer", completer: nil, input: "d\t\r", want: "d"},
+		{
+			name: "Completes word at cursor",
+			completer: completion.CompleterFunc(func(input string, cursor int) ([]string, int) {
+				return []string{"namespace"}, strings.LastIndex(input[:cursor], " ") + 1
+			}),
+			input: "get na -o\x1b[D\x1b[D\x1b[D\t\r",
+			want:  "get namespace -o",
+		},
+		{
+			name: "Invalid start is clamped",
+			completer: completion.CompleterFunc(func(input string, cursor int) ([]string, int) {
+				return []string{"!"}, cursor + 5
+			}),
+			input: "a\t\r",
+			want:  "a!",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			e := newLineEditor(bufio.NewReader(strings.NewReader(tt.input)), out)
+			e.completer = tt.completer
+			got, err := e.readLine("> ", nil)
+			if err != nil {
+				t.Fatalf("readLine() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("readLine() = %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("readLine() output = %q, want %q", out.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestPrompt_Completer(t *testing.T) {
+	p := &Prompt{PromptMessage: "Branch", Completer: completion.MakeListCompleter("develop")}
+	p.SetOptions(WithReader(strings.NewReader("d\t\r")), WithWriter(new(bytes.Buffer)), WithLineEditor())
+	if got, err := p.Show(); err != nil || got != "develop" {
+		t.Errorf("Show() = %v, %v, want %v", got, err, "develop")
+	}
+}
@@ -0,0 +1,27 @@
+package prompt
+
+import (
+	"strings"
+)
+
+const liveValidMark = "✔"
+
+// getLiveStatus validates line as it would be when Enter is pressed, and returns the status displayed below the
+// input by LiveValidation: nothing for empty input, a mark for valid input, otherwise the error message. Only the
+// validators run on every change; OutputSerializerFunc, which may be slow or have side effects, runs once on Enter
+func (h *Prompt) getLiveStatus(line string) string {
+	if !h.SuppressTrimWhitespace {
+		line = strings.TrimSpace(line)
+	}
+	if line == "" {
+		return ""
+	}
+	err := h.validate(line)
+	switch {
+	case err == nil:
+		return h.style(h.getTheme().Summary, liveValidMark)
+	case err == errInvalidInput:
+		return h.style(h.getTheme().Error, h.getInvalidInputMessage())
+	}
+	return h.style(h.getTheme().Error, err.Error())
+}
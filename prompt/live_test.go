@@ -0,0 +1,81 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPrompt_getLiveStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		prompt Prompt
+		line   string
+		want   string
+	}{
+		{name: "Empty", prompt: Prompt{InputValidatorRegex: regexp.MustCompile(`^\d+$`)}, line: "  ", want: ""},
+		{name: "Valid", prompt: Prompt{InputValidatorRegex: regexp.MustCompile(`^\d+$`)}, line: " 42 ", want: "✔"},
+		{name: "Invalid", prompt: Prompt{InputValidatorRegex: regexp.MustCompile(`^\d+$`), InvalidInputMessage: "Enter a number"}, line: "4x", want: "Enter a number"},
+		{name: "Not trimmed", prompt: Prompt{InputValidatorRegex: regexp.MustCompile(`^\d+$`), SuppressTrimWhitespace: true}, line: " 42", want: "Invalid Input"},
+		{name: "Error validator", prompt: Prompt{InputErrorValidatorFunc: func(s string) error { return errors.New("Too short") }}, line: "a", want: "Too short"},
+		{name: "Serializer not run", prompt: Prompt{OutputSerializerFunc: func(s string) (interface{}, error) { return strconv.Atoi(s) }}, line: "x", want: "✔"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.prompt.getLiveStatus(tt.line); got != tt.want {
+				t.Errorf("getLiveStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompt_LiveValidation(t *testing.T) {
+	out := new(bytes.Buffer)
+	p := &Prompt{PromptMessage: "Age", InputValidatorRegex: regexp.MustCompile(`^\d+$`), LiveValidation: true}
+	p.SetOptions(WithLineEditor(), WithReader(strings.NewReader("4x\x7f\r")), WithWriter(out))
+	got, err := p.Show()
+	if err != nil || got != "4" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "4")
+	}
+	for _, want := range []string{
+		"\x1b7\x1b[B\r✔\x1b[K\x1b8",
+		"\x1b7\x1b[B\rInvalid Input\x1b[K\x1b8",
+		"\x1b7\x1b[B\r\x1b[K\x1b8\r\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Show() output = %q, want %q", out.String(), want)
+		}
+	}
+}
+
+func TestPrompt_LiveValidationSerializesOnEnter(t *testing.T) {
+	var serialized []string
+	p := &Prompt{PromptMessage: "Age", LiveValidation: true, OutputSerializerFunc: func(s string) (interface{}, error) {
+		serialized = append(serialized, s)
+		return strconv.Atoi(s)
+	}}
+	p.SetOptions(WithLineEditor(), WithReader(strings.NewReader("x\r42\r")), WithWriter(new(bytes.Buffer)))
+	got, err := p.Show()
+	if err != nil || got != 42 {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, 42)
+	}
+	if want := []string{"x", "42"}; strings.Join(serialized, ",") != strings.Join(want, ",") {
+		t.Errorf("OutputSerializerFunc called with %q, want once per Enter %q", serialized, want)
+	}
+}
+
+func TestPrompt_LiveValidationStillValidatesOnEnter(t *testing.T) {
+	out := new(bytes.Buffer)
+	p := &Prompt{PromptMessage: "Age", InputValidatorRegex: regexp.MustCompile(`^\d+$`), LiveValidation: true}
+	p.SetOptions(WithLineEditor(), WithReader(strings.NewReader("x\r7\r")), WithWriter(out))
+	got, err := p.Show()
+	if err != nil || got != "7" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "7")
+	}
+	if !strings.Contains(out.String(), "\nInvalid Input [x]\n\n") {
+		t.Errorf("Show() output = %q, want the invalid input message", out.String())
+	}
+}
@@ -0,0 +1,15 @@
+package prompt
+
+import (
+	"github.com/bchivari/go-cli-prompt/i18n"
+)
+
+// getLocalizer returns a Localizer for the locale set with SetOption(WithLocale), or the locale of the i18n package
+func (h *Prompt) getLocalizer() i18n.Localizer {
+	return i18n.DefaultCatalog.Localizer(h.locale)
+}
+
+// message returns the built-in message id in the locale of the prompt
+func (h *Prompt) message(id i18n.MessageID, args ...interface{}) string {
+	return h.getLocalizer().Message(id, args...)
+}
@@ -0,0 +1,50 @@
+package prompt
+
+import (
+	"bytes"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"strings"
+	"testing"
+)
+
+func TestPrompt_Locale(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   Prompt
+		options  []Opt
+		input    string
+		wantText string
+	}{
+		{name: "German", options: []Opt{WithLocale("de_DE.UTF-8")}, input: "\nBob\n", wantText: "\nUngültige Eingabe [leer]\n\n"},
+		{name: "Japanese", options: []Opt{WithLocale("ja")}, input: "\nBob\n", wantText: "\n入力が無効です [空]\n\n"},
+		{name: "InvalidInputMessage", prompt: Prompt{InvalidInputMessage: "Name required"}, options: []Opt{WithLocale("de")}, input: "\nBob\n", wantText: "\nName required [leer]\n\n"},
+		{name: "Multi-line hint", prompt: Prompt{IsMultiLine: true}, options: []Opt{WithLocale("de")}, input: "Bob\n", wantText: "Name: (Mit Strg-D beenden)\n"},
+		{name: "Confirm", prompt: Prompt{IsPassword: true, ConfirmPassword: true}, options: []Opt{WithLocale("de")}, input: "a\nb\nBob\nBob\n", wantText: "Name bestätigen: \n\nDie Eingaben stimmen nicht überein\n\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := tt.prompt
+			p.PromptMessage = "Name"
+			p.SetOptions(append(tt.options, WithReader(strings.NewReader(tt.input)), WithWriter(out))...)
+			if got, err := p.Show(); err != nil || got != "Bob" {
+				t.Fatalf("Show() = %v, %v, want %v", got, err, "Bob")
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestPrompt_DefaultLocale(t *testing.T) {
+	i18n.SetLocale("de")
+	defer i18n.SetLocale(i18n.FallbackLocale)
+	p := &Prompt{}
+	if got := p.getInvalidInputMessage(); got != "Ungültige Eingabe" {
+		t.Errorf("getInvalidInputMessage() = %q, want the locale of the i18n package", got)
+	}
+	if got := (PathOptions{Kind: PathFile, MustExist: true, Extensions: []string{".yaml"}}).describe(i18n.Default()); got != "Erwartet wird eine vorhandene Datei mit der Endung .yaml" {
+		t.Errorf("describe() = %q, want German", got)
+	}
+}
@@ -0,0 +1,122 @@
+package prompt
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// liveLine is a line redrawn in place on a terminal, ie: the input of the line editor, which log output written with
+// a LogWriter goes above
+type liveLine struct {
+	w      io.Writer   // The writer the line is drawn on
+	lock   sync.Locker // Held while the line is changed or drawn
+	clear  func()      // Erases the line; Called with lock held
+	redraw func()      // Draws the line again; Called with lock held
+}
+
+// liveLines are the prompts waiting for input on a terminal, newest last
+var liveLines = struct {
+	sync.Mutex
+	lines []*liveLine
+}{}
+
+// addLiveLine registers l until the returned func is called
+func addLiveLine(l *liveLine) func() {
+	liveLines.Lock()
+	liveLines.lines = append(liveLines.lines, l)
+	liveLines.Unlock()
+	return func() {
+		liveLines.Lock()
+		defer liveLines.Unlock()
+		for i, r := range liveLines.lines {
+			if r == l {
+				liveLines.lines = append(liveLines.lines[:i], liveLines.lines[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// findLiveLine returns the newest prompt waiting for input on the terminal of w, or a spinner or progress bar
+// drawn on it; nil if there is none
+func findLiveLine(w io.Writer) *liveLine {
+	liveLines.Lock()
+	for i := len(liveLines.lines) - 1; i >= 0; i-- {
+		if l := liveLines.lines[i]; sameTerminal(l.w, w) {
+			liveLines.Unlock()
+			return l
+		}
+	}
+	liveLines.Unlock()
+
+	activities.Lock()
+	defer activities.Unlock()
+	for i := len(activities.running) - 1; i >= 0; i-- {
+		if a := activities.running[i]; a.tty && sameTerminal(a.w, w) {
+			return &liveLine{w: a.w, lock: &a.mu, clear: a.clearLine, redraw: a.redrawLine}
+		}
+	}
+	return nil
+}
+
+// sameTerminal reports if a and b are the same writer, or files open on the same terminal, ie: os.Stdout and
+// os.Stderr of an interactive shell
+func sameTerminal(a io.Writer, b io.Writer) bool {
+	if sameWriter(a, b) {
+		return true
+	}
+	fa, ok := a.(*os.File)
+	if !ok || !isTerminalWriter(a) {
+		return false
+	}
+	fb, ok := b.(*os.File)
+	if !ok || !isTerminalWriter(b) {
+		return false
+	}
+	sa, err := fa.Stat()
+	if err != nil {
+		return false
+	}
+	sb, err := fb.Stat()
+	return err == nil && os.SameFile(sa, sb)
+}
+
+// LogWriter is an io.Writer for log output, ie: log.SetOutput(prompt.NewLogWriter(os.Stderr)) or
+// slog.NewTextHandler(prompt.NewLogWriter(os.Stderr), nil). While a prompt is waiting for input on the same
+// terminal, the prompt line is erased, the log output is written in its place and the prompt is drawn again below it
+// with the input typed so far; Otherwise output is written through unchanged. Input typed in a prompt which doesn't
+// use the line editor, ie: with SuppressLineEditor set, isn't drawn again
+type LogWriter struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewLogWriter returns a LogWriter writing to w
+func NewLogWriter(w io.Writer) *LogWriter {
+	return &LogWriter{w: w}
+}
+
+// Write writes p above the prompt waiting for input on the terminal of the LogWriter, if there is one. Lines are
+// ended with "\r\n", as the terminal may be in raw mode, and a missing final line break is added
+func (lw *LogWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	l := findLiveLine(lw.w)
+	if l == nil {
+		return lw.w.Write(p)
+	}
+	text := strings.ReplaceAll(strings.ReplaceAll(string(p), "\r\n", "\n"), "\n", "\r\n")
+	if !strings.HasSuffix(text, "\r\n") {
+		text += "\r\n"
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.clear()
+	if _, err := io.WriteString(lw.w, text); err != nil {
+		return 0, err
+	}
+	l.redraw()
+	return len(p), nil
+}
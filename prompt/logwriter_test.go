@@ -0,0 +1,102 @@
+package prompt
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func waitForOutput(t *testing.T, out *syncBuffer, want string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(out.String(), want) {
+		if time.Now().After(deadline) {
+			t.Fatalf("output = %q, want %q", out.String(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLogWriter_NoPrompt(t *testing.T) {
+	out := new(bytes.Buffer)
+	lw := NewLogWriter(out)
+	if n, err := lw.Write([]byte("started\n")); n != 8 || err != nil {
+		t.Fatalf("Write() = %v, %v, want %v", n, err, 8)
+	}
+	lw.Write([]byte("partial"))
+	if want := "started\npartial"; out.String() != want {
+		t.Errorf("Write() output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestLogWriter_LineEditor(t *testing.T) {
+	r, w := io.Pipe()
+	out := &syncBuffer{}
+	p := &Prompt{PromptMessage: "Name"}
+	p.SetOptions(WithReader(r), WithWriter(out), WithLineEditor())
+	result := make(chan interface{}, 1)
+	go func() {
+		got, _ := p.Show()
+		result <- got
+	}()
+	w.Write([]byte("Bo"))
+	waitForOutput(t, out, "\rName: Bo\x1b[K")
+
+	lw := NewLogWriter(out)
+	lw.Write([]byte("first\nsecond\n"))
+	lw.Write([]byte("third"))
+	w.Write([]byte("b\r"))
+	if got := <-result; got != "Bob" {
+		t.Errorf("Show() = %v, want %v", got, "Bob")
+	}
+	// The prompt line is erased, the log lines written in its place and the prompt redrawn with the typed input
+	for _, want := range []string{
+		"\r\x1b[Jfirst\r\nsecond\r\n\rName: Bo\x1b[K",
+		"\r\x1b[Jthird\r\n\rName: Bo\x1b[K",
+		"\rName: Bob\x1b[K\r\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output = %q, want %q", out.String(), want)
+		}
+	}
+
+	// Written through once the prompt is answered
+	lw.Write([]byte("done\n"))
+	if !strings.HasSuffix(out.String(), "\r\ndone\n") {
+		t.Errorf("output = %q, want the log line written through", out.String())
+	}
+}
+
+func TestLogWriter_Select(t *testing.T) {
+	r, w := io.Pipe()
+	out := &syncBuffer{}
+	s := &Select{Prompt: Prompt{PromptMessage: "Region"}, Options: MakeOptions("us-east-1", "eu-west-1")}
+	s.SetOptions(WithReader(r), WithWriter(out), WithLineEditor())
+	result := make(chan interface{}, 1)
+	go func() {
+		got, _ := s.Show()
+		result <- got
+	}()
+	w.Write([]byte("eu"))
+	waitForOutput(t, out, "Region: eu")
+
+	NewLogWriter(out).Write([]byte("refreshed\n"))
+	w.Write([]byte("\r"))
+	if got := <-result; got != "eu-west-1" {
+		t.Errorf("Show() = %v, want %v", got, "eu-west-1")
+	}
+	if want := "Region: eu\r\x1b[Jrefreshed\r\n\r\x1b[JRegion: eu\r\n> "; !strings.Contains(out.String(), want) {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestSameTerminal(t *testing.T) {
+	a, b := new(bytes.Buffer), new(bytes.Buffer)
+	if !sameTerminal(a, a) {
+		t.Errorf("sameTerminal() = false for the same writer")
+	}
+	if sameTerminal(a, b) {
+		t.Errorf("sameTerminal() = true for different writers")
+	}
+}
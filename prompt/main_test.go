@@ -0,0 +1,15 @@
+package prompt
+
+import (
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"os"
+	"testing"
+)
+
+// TestMain pins built-in messages to English and disables accessible mode, whatever the environment running the
+// tests, as the tests compare prompt output with the default English rendering
+func TestMain(m *testing.M) {
+	i18n.SetLocale(i18n.FallbackLocale)
+	os.Unsetenv(envAccessible)
+	os.Exit(m.Run())
+}
@@ -0,0 +1,173 @@
+package prompt
+
+import (
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"unicode"
+)
+
+const (
+	editorErrorTemplate       = "editor %v failed: %w"
+	defaultEditorFilePattern  = "*.txt"
+	defaultEditorUnix         = "vi"
+	defaultEditorWindows      = "notepad"
+	editorEnvVisual           = "VISUAL"
+	editorEnvEditor           = "EDITOR"
+	editorWindowsLineEnding   = "\r\n"
+	editorLineEnding          = "\n"
+	multiLineContinuationText = ""
+)
+
+// readMultiLineInput reads lines until the sentinel line, Ctrl-D or the end of input
+func (h *Prompt) readMultiLineInput() (string, error) {
+	var lines []string
+	for {
+		line, err := h.readLine(multiLineContinuationText)
+		if err == io.EOF && len(lines) > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if h.MultiLineSentinel != "" && line == h.MultiLineSentinel {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (h *Prompt) getMultiLineHint() string {
+	if h.MultiLineSentinel != "" {
+		return h.message(i18n.MultiLineSentinelHint, h.MultiLineSentinel)
+	}
+	return h.message(i18n.MultiLineEOFHint)
+}
+
+// readEditorInput waits for Enter, then opens DefaultAsString, or the text rejected by the previous attempt, in the
+// user's editor and returns the saved text
+func (h *Prompt) readEditorInput() (string, error) {
+	if _, err := h.readLine(h.getPromptText()); err != nil {
+		return "", err
+	}
+	if h.editorContent == nil {
+		content := h.DefaultAsString
+		h.editorContent = &content
+	}
+	text, err := h.runEditor(*h.editorContent)
+	if err != nil {
+		return "", err
+	}
+	h.editorContent = &text
+	return text, nil
+}
+
+func (h *Prompt) runEditor(text string) (string, error) {
+	f, err := os.CreateTemp("", h.getEditorFilePattern())
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	args := append(getEditorCommand(), f.Name())
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = h.getEditorStdin(), h.getEditorStdout(), os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf(editorErrorTemplate, args[0], err)
+	}
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(string(data), editorWindowsLineEnding, editorLineEnding), nil
+}
+
+// getEditorCommand returns the command of $VISUAL or $EDITOR, which may include arguments, ie: "code --wait". Words
+// are split as by a shell, so a path with spaces can be quoted, ie: "'/opt/My Editor/edit' --wait"
+func getEditorCommand() []string {
+	for _, env := range []string{editorEnvVisual, editorEnvEditor} {
+		if command := splitCommand(os.Getenv(env), runtime.GOOS != "windows"); len(command) > 0 {
+			return command
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return []string{defaultEditorWindows}
+	}
+	return []string{defaultEditorUnix}
+}
+
+// splitCommand splits s into words at unquoted whitespace. Single and double quotes group words and are removed. If
+// escapes is set a backslash, outside single quotes, takes the next character literally; Windows paths need it unset
+func splitCommand(s string, escapes bool) []string {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, c := range s {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case escapes && c == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote, inWord = c, true
+		case unicode.IsSpace(c):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+func (h *Prompt) getEditorFilePattern() string {
+	if h.EditorFilePattern != "" {
+		return h.EditorFilePattern
+	}
+	return defaultEditorFilePattern
+}
+
+// getEditorStdin returns the input if it is a file, ie: a terminal, since the editor needs direct access to it
+func (h *Prompt) getEditorStdin() *os.File {
+	if f, ok := h.getInputReader().(*os.File); ok {
+		return f
+	}
+	return os.Stdin
+}
+
+func (h *Prompt) getEditorStdout() *os.File {
+	if f, ok := h.outputWriter.(*os.File); ok {
+		return f
+	}
+	return os.Stdout
+}
@@ -0,0 +1,196 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPrompt_MultiLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		prompt     Prompt
+		lineEditor bool
+		input      string
+		want       interface{}
+		wantErr    error
+		wantText   string
+	}{
+		{name: "Ends at end of input", input: "first line\n  indented\n\nlast", want: "first line\n  indented\n\nlast", wantText: "Message: (End with Ctrl-D)\n"},
+		{name: "Ends at sentinel", prompt: Prompt{MultiLineSentinel: "."}, input: "one\ntwo\n.\nnext\n", want: "one\ntwo", wantText: "Message: (End with a line containing only ., or Ctrl-D)\n"},
+		{name: "Trimmed", input: "\n  body  \n\n", want: "body"},
+		{name: "SuppressTrimWhitespace", prompt: Prompt{SuppressTrimWhitespace: true}, input: "\n  body  \n", want: "\n  body  "},
+		{name: "Empty uses default", prompt: Prompt{MultiLineSentinel: ".", DefaultAsString: "default"}, input: ".\n", want: "default"},
+		{name: "Validated", prompt: Prompt{MultiLineSentinel: ".", InputValidatorFunc: func(s string) bool { return strings.Contains(s, "\n") }}, input: "one\n.\none\ntwo\n.\n", want: "one\ntwo", wantText: "Invalid Input [one]"},
+		{name: "No input", input: "", wantErr: io.EOF},
+		{name: "Line editor Ctrl-D", lineEditor: true, input: "one\rtwo\r\x04", want: "one\ntwo"},
+		{name: "Line editor Ctrl-D deletes within line", lineEditor: true, input: "ab\x01\x04\r\x04", want: "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := tt.prompt
+			p.PromptMessage = "Message"
+			p.IsMultiLine = true
+			p.SetOptions(WithReader(strings.NewReader(tt.input)), WithWriter(out))
+			if tt.lineEditor {
+				p.SetOptions(WithLineEditor())
+			}
+			got, err := p.Show()
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("Show() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+// TestEditorHelperProcess is run as $EDITOR by the editor tests; It appends "!" and a Windows line ending to the
+// edited file, or empties it if PROMPT_EDITOR_TRUNCATE is set
+func TestEditorHelperProcess(t *testing.T) {
+	if os.Getenv("PROMPT_EDITOR_HELPER") != "1" {
+		return
+	}
+	if os.Getenv("PROMPT_EDITOR_FAIL") == "1" {
+		os.Exit(1)
+	}
+	name := os.Args[len(os.Args)-1]
+	if os.Getenv("PROMPT_EDITOR_TRUNCATE") == "1" {
+		os.WriteFile(name, nil, 0600)
+		os.Exit(0)
+	}
+	data, _ := os.ReadFile(name)
+	os.WriteFile(name, append(data, "!\r\n"...), 0600)
+	os.Exit(0)
+}
+
+func useHelperEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", os.Args[0]+" -test.run=TestEditorHelperProcess --")
+	t.Setenv("PROMPT_EDITOR_HELPER", "1")
+}
+
+func TestPrompt_Editor(t *testing.T) {
+	useHelperEditor(t)
+	out := new(bytes.Buffer)
+	p := &Prompt{
+		PromptMessage:      "Description",
+		IsEditor:           true,
+		DefaultAsString:    "draft",
+		InputValidatorFunc: func(s string) bool { return strings.Count(s, "!") == 2 },
+	}
+	p.SetOptions(WithReader(strings.NewReader("\n\n")), WithWriter(out))
+
+	got, err := p.Show()
+	if want := "draft!\n!"; err != nil || got != want {
+		t.Fatalf("Show() = %q, %v, want %q", got, err, want)
+	}
+	for _, want := range []string{"Description [Enter to launch editor]: ", "Invalid Input [draft!]"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Show() output = %q, want %q", out.String(), want)
+		}
+	}
+
+	p = &Prompt{PromptMessage: "Description", IsEditor: true}
+	p.SetOptions(WithReader(strings.NewReader("")), WithWriter(new(bytes.Buffer)))
+	if _, err := p.Show(); !errors.Is(err, io.EOF) {
+		t.Errorf("Show() error = %v, want %v", err, io.EOF)
+	}
+
+	t.Setenv("PROMPT_EDITOR_FAIL", "1")
+	p = &Prompt{PromptMessage: "Description", IsEditor: true}
+	p.SetOptions(WithReader(strings.NewReader("\n")), WithWriter(new(bytes.Buffer)))
+	if _, err := p.Show(); err == nil || !strings.Contains(err.Error(), "editor") {
+		t.Errorf("Show() error = %v, want editor failure", err)
+	}
+}
+
+func TestPrompt_EditorEmptied(t *testing.T) {
+	useHelperEditor(t)
+	t.Setenv("PROMPT_EDITOR_TRUNCATE", "1")
+	p := &Prompt{PromptMessage: "Description", IsEditor: true, DefaultAsString: "template text", AllowNil: true}
+	p.SetOptions(WithReader(strings.NewReader("\n")), WithWriter(new(bytes.Buffer)))
+	if got, err := p.Show(); err != nil || got != nil {
+		t.Errorf("Show() = %q, %v, want nil", got, err)
+	}
+
+	out := new(bytes.Buffer)
+	p = &Prompt{PromptMessage: "Description", IsEditor: true, DefaultAsString: "template text"}
+	p.SetOptions(WithReader(strings.NewReader("\n")), WithWriter(out))
+	if got, err := p.Show(); !errors.Is(err, io.EOF) {
+		t.Errorf("Show() = %q, %v, want %v", got, err, io.EOF)
+	}
+	if want := "Invalid Input [null]"; !strings.Contains(out.String(), want) {
+		t.Errorf("Show() output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestGetEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "nano")
+	if got := strings.Join(getEditorCommand(), " "); got != "code --wait" {
+		t.Errorf("getEditorCommand() = %q, want $VISUAL", got)
+	}
+	t.Setenv("VISUAL", "")
+	if got := strings.Join(getEditorCommand(), " "); got != "nano" {
+		t.Errorf("getEditorCommand() = %q, want $EDITOR", got)
+	}
+	t.Setenv("EDITOR", " ")
+	if got := getEditorCommand(); len(got) != 1 || (got[0] != defaultEditorUnix && got[0] != defaultEditorWindows) {
+		t.Errorf("getEditorCommand() = %q, want default editor", got)
+	}
+}
+
+func Test_splitCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		escapes bool
+		want    []string
+	}{
+		{name: "Words", s: " code  --wait ", escapes: true, want: []string{"code", "--wait"}},
+		{name: "Double quoted path", s: `"/opt/My Editor/edit" --wait`, escapes: true, want: []string{"/opt/My Editor/edit", "--wait"}},
+		{name: "Single quoted path", s: `'/opt/My Editor/edit' -f`, escapes: true, want: []string{"/opt/My Editor/edit", "-f"}},
+		{name: "Escaped space", s: `/opt/My\ Editor/edit`, escapes: true, want: []string{"/opt/My Editor/edit"}},
+		{name: "Quotes within a word", s: `--name="a b"c`, escapes: true, want: []string{"--name=a bc"}},
+		{name: "Empty quotes", s: `edit ""`, escapes: true, want: []string{"edit", ""}},
+		{name: "Windows path", s: `"C:\Program Files\Editor\edit.exe" /w`, escapes: false, want: []string{`C:\Program Files\Editor\edit.exe`, "/w"}},
+		{name: "Blank", s: " ", escapes: true, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitCommand(tt.s, tt.escapes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompt_EditorQuotedPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need privileges on Windows")
+	}
+	dir := filepath.Join(t.TempDir(), "My Editor")
+	os.Mkdir(dir, 0700)
+	editor := filepath.Join(dir, "edit")
+	if err := os.Symlink(os.Args[0], editor); err != nil {
+		t.Skipf("Symlink() error = %v", err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "'"+editor+"' -test.run=TestEditorHelperProcess --")
+	t.Setenv("PROMPT_EDITOR_HELPER", "1")
+
+	p := &Prompt{PromptMessage: "Description", IsEditor: true, DefaultAsString: "draft"}
+	p.SetOptions(WithReader(strings.NewReader("\n")), WithWriter(new(bytes.Buffer)))
+	if got, err := p.Show(); err != nil || got != "draft!" {
+		t.Errorf("Show() = %q, %v, want %q", got, err, "draft!")
+	}
+}
@@ -17,6 +17,16 @@ func (h *Prompt) SetOptions(opts ...Opt) error {
 	return nil
 }
 
+// SetOptions will call all provided Opt objects against every Prompt in the PromptList
+func (c *PromptList) SetOptions(opts ...Opt) error {
+	for i := range *c {
+		if err := (*c)[i].SetOptions(opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // WithWriter returns an option func which sets a customized (non stdout) io.Writer
 func WithWriter(w io.Writer) Opt {
 	return func(p *Prompt) error {
@@ -25,10 +35,89 @@ func WithWriter(w io.Writer) Opt {
 	}
 }
 
-// WithReader returns an option func which sets a customized (non stdout) io.Reader
+// WithReader returns an option func which sets a customized (non stdin) io.Reader. All prompts the returned option is
+// applied to share one buffer over r, so a PromptList set up with a single WithReader option can be driven from one
+// stream
 func WithReader(r io.Reader) Opt {
+	in := newSharedInput(r)
 	return func(p *Prompt) error {
 		p.inputReader = r
+		p.input = in
+		p.scanner = nil
+		return nil
+	}
+}
+
+// WithLineEditor returns an option func which reads input with the built-in line editor even if the input is not a
+// terminal; Useful for driving the editor from tests
+func WithLineEditor() Opt {
+	return func(p *Prompt) error {
+		p.forceLineEditor = true
+		return nil
+	}
+}
+
+// WithRenderer returns an option func which sets the Renderer formatting the displayed text, ie: a TemplateRenderer
+func WithRenderer(r Renderer) Opt {
+	return func(p *Prompt) error {
+		p.renderer = r
+		return nil
+	}
+}
+
+// WithTheme returns an option func which styles the output with t, ie: DefaultTheme. Colors are only used if the
+// output is a terminal and NO_COLOR isn't set, unless the level is set with WithColorLevel
+func WithTheme(t Theme) Opt {
+	return func(p *Prompt) error {
+		p.theme = &t
+		return nil
+	}
+}
+
+// WithColorLevel returns an option func which sets the colors the output displays instead of detecting them, ie:
+// ColorLevelNone to disable colors
+func WithColorLevel(l ColorLevel) Opt {
+	return func(p *Prompt) error {
+		p.colorLevel = l
+		return nil
+	}
+}
+
+// WithAnsweredSummary returns an option func which, if the output is a terminal, replaces the prompt and any error
+// messages displayed while answering it with a single summary line once answered, ie: "✔ Name: Bob". Keeps the
+// scrollback of long series of prompts clean
+func WithAnsweredSummary() Opt {
+	return func(p *Prompt) error {
+		p.answeredSummary = true
+		return nil
+	}
+}
+
+// WithTerminalWidth returns an option func which wraps text at cols columns instead of the width of the output
+// terminal, which is queried again whenever the terminal is resized. Text isn't wrapped if the output isn't a terminal
+// and no width is set; 0 restores querying the terminal
+func WithTerminalWidth(cols int) Opt {
+	return func(p *Prompt) error {
+		p.terminalWidth = cols
+		return nil
+	}
+}
+
+// WithAccessibleMode returns an option func which enables accessible mode, as the ACCESSIBLE environment variable
+// does: Input is read as plain lines without redrawing, a Select lists numbered options, no colors or other escape
+// sequences are written and every accepted answer is confirmed with a line of text, for screen readers
+func WithAccessibleMode() Opt {
+	return func(p *Prompt) error {
+		p.accessible = true
+		return nil
+	}
+}
+
+// WithLocale returns an option func which sets the locale of built-in messages, ie: "de" or "ja_JP", instead of the
+// locale of the i18n package, which is detected from LC_ALL, LC_MESSAGES and LANG unless set with i18n.SetLocale
+func WithLocale(locale string) Opt {
+	return func(p *Prompt) error {
+		p.locale = locale
 		return nil
 	}
 }
@@ -79,6 +79,75 @@ func TestWithReader(t *testing.T) {
 	assertEqual(t, myReader, p.inputReader)
 }
 
+func TestWithLineEditor(t *testing.T) {
+	p := &Prompt{PromptMessage: "My Message"}
+	p.SetOptions(WithLineEditor())
+	assertEqual(t, true, p.forceLineEditor)
+}
+
+func TestWithHistory(t *testing.T) {
+	p := &Prompt{PromptMessage: "My Message"}
+	h, _ := NewHistory("", 0)
+	p.SetOptions(WithHistory(h))
+	assertEqual(t, h, p.history)
+}
+
+func TestWithRenderer(t *testing.T) {
+	p := &Prompt{PromptMessage: "My Message"}
+	r := &TemplateRenderer{}
+	p.SetOptions(WithRenderer(r))
+	assertEqual(t, r, p.renderer)
+}
+
+func TestWithTheme(t *testing.T) {
+	p := &Prompt{PromptMessage: "My Message"}
+	p.SetOptions(WithTheme(MonochromeTheme), WithColorLevel(ColorLevel256))
+	assertEqual(t, MonochromeTheme, *p.theme)
+	assertEqual(t, ColorLevel256, p.colorLevel)
+}
+
+func TestWithAnsweredSummary(t *testing.T) {
+	p := &Prompt{PromptMessage: "My Message"}
+	p.SetOptions(WithAnsweredSummary())
+	assertEqual(t, true, p.answeredSummary)
+}
+
+func TestWithLocale(t *testing.T) {
+	p := &Prompt{PromptMessage: "My Message"}
+	p.SetOptions(WithLocale("de"))
+	assertEqual(t, "de", p.locale)
+}
+
+func TestWithAccessibleMode(t *testing.T) {
+	p := &Prompt{PromptMessage: "My Message"}
+	p.SetOptions(WithAccessibleMode())
+	assertEqual(t, true, p.accessible)
+	assertEqual(t, true, p.isAccessible())
+}
+
+func TestWithTerminalWidth(t *testing.T) {
+	p := &Prompt{PromptMessage: "My Message"}
+	p.SetOptions(WithTerminalWidth(40))
+	assertEqual(t, 40, p.terminalWidth)
+	assertEqual(t, 40, p.getTerminalWidth())
+}
+
+func TestPromptList_SetOptions(t *testing.T) {
+	l := MakePromptList(Prompt{MapKey: "one"}, Prompt{MapKey: "two"})
+	myWriter := new(bytes.Buffer)
+	if err := l.SetOptions(WithWriter(myWriter)); err != nil {
+		t.Fatalf("SetOptions() error = %v", err)
+	}
+	for _, p := range *l {
+		assertEqual(t, myWriter, p.outputWriter)
+	}
+
+	wantErr := fmt.Errorf("some error")
+	if err := l.SetOptions(func(p *Prompt) error { return wantErr }); err != wantErr {
+		t.Errorf("SetOptions() error = %v, wantErr %v", err, wantErr)
+	}
+}
+
 func assertEqual(t *testing.T, a interface{}, b interface{}) {
 	if a != b {
 		t.Fatalf("%s != %s", a, b)
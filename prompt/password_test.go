@@ -0,0 +1,254 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"github.com/bchivari/go-cli-prompt/validation"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPrompt_PasswordMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     rune
+		input    string
+		want     interface{}
+		wantErr  error
+		wantText string
+	}{
+		{name: "Wide mask backspace", mask: '＊', input: "abc\x7f\r", want: "ab", wantText: "＊＊＊\b \b\b \b\n"},
+		{name: "Wide mask Ctrl-U", mask: '＊', input: "ab\x15c\r", want: "c", wantText: strings.Repeat("\b \b", 4) + "＊\n"},
+		{name: "Mask per rune", input: "pässwörd\r", want: "pässwörd", wantText: "Password: ********\n"},
+		{name: "Backspace", input: "secrexx\x7f\x7ft\r", want: "secret", wantText: "*******\b \b\b \b*\n"},
+		{name: "Backspace on empty", input: "\x7fab\r", want: "ab", wantText: "Password: **\n"},
+		{name: "Ctrl-U", input: "wrong\x15ok\r", want: "ok", wantText: strings.Repeat("\b \b", 5) + "**"},
+		{name: "Arrows ignored", input: "a\x1b[Db\r", want: "ab"},
+		{name: "Unterminated", input: "ab", want: "ab"},
+		{name: "Interrupt", input: "ab\x03", wantErr: ErrInterrupted},
+		{name: "EOF", input: "\x04", wantErr: io.EOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := &Prompt{PromptMessage: "Password", IsPassword: true, PasswordMask: tt.mask}
+			if p.PasswordMask == 0 {
+				p.PasswordMask = '*'
+			}
+			p.SetOptions(WithLineEditor(), WithReader(strings.NewReader(tt.input)), WithWriter(out))
+			got, err := p.Show()
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("Show() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+			if strings.Contains(out.String(), "ab") || strings.Contains(out.String(), "secret") {
+				t.Errorf("password was echoed: %q", out.String())
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestPrompt_ConfirmPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   Prompt
+		options  []Opt
+		input    string
+		want     interface{}
+		wantErr  error
+		wantText string
+	}{
+		{name: "Match", input: "hunter2\nhunter2\n", want: "hunter2", wantText: "Password: \nConfirm Password: \n"},
+		{
+			name:     "Mismatch re-prompts",
+			input:    "hunter2\nhunter3\nhunter4\nhunter4\n",
+			want:     "hunter4",
+			wantText: "Password: \nConfirm Password: \n\nEntries do not match\n\nPassword: \nConfirm Password: \n",
+		},
+		{name: "Custom message", prompt: Prompt{ConfirmPromptMessage: "Repeat"}, input: "a\na\n", want: "a", wantText: "Repeat: "},
+		{name: "Masked", options: []Opt{WithLineEditor()}, input: "ab\rab\r", want: "ab", wantText: "Password: **\nConfirm Password: **\n"},
+		{name: "Validated after confirmation", prompt: Prompt{InputValidatorFunc: func(s string) bool { return len(s) > 1 }}, input: "a\na\nab\nab\n", want: "ab", wantText: "Invalid Input"},
+		{name: "Confirmation EOF", input: "hunter2\n", wantErr: io.EOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := tt.prompt
+			p.PromptMessage = "Password"
+			p.IsPassword = true
+			p.ConfirmPassword = true
+			p.PasswordMask = '*'
+			p.SetOptions(append(tt.options, WithReader(strings.NewReader(tt.input)), WithWriter(out))...)
+			got, err := p.Show()
+			if (tt.wantErr == nil) != (err == nil) || got != tt.want {
+				t.Fatalf("Show() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestPromptList_PasswordFromSingleStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   []Opt
+		input     string
+		wantInput []string
+	}{
+		{name: "LF", input: "bob\ncorrect horse battery staple \n42\n", wantInput: []string{"bob\n", redactedData, "42\n"}},
+		{name: "CRLF", input: "bob\r\ncorrect horse battery staple \r\n42\r\n", wantInput: []string{"bob\r\n", redactedData, "42\r\n"}},
+		{name: "Line editor", options: []Opt{WithLineEditor()}, input: "bob\rcorrect horse battery staple \r42\r",
+			wantInput: []string{"b", "o", "b", "\r", redactedData, "4", "2", "\r"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := MakePromptList(
+				Prompt{PromptMessage: "Name", MapKey: "name"},
+				Prompt{PromptMessage: "Password", MapKey: "password", IsPassword: true, PasswordMask: '*'},
+				Prompt{PromptMessage: "Age", MapKey: "age"},
+			)
+			var recording bytes.Buffer
+			list.SetOptions(append(tt.options, WithReader(strings.NewReader(tt.input)), WithWriter(new(bytes.Buffer)),
+				WithRecorder(NewRecorder(&recording)))...)
+			got, err := list.Show()
+			if err != nil {
+				t.Fatalf("Show() error = %v", err)
+			}
+			want := map[string]interface{}{"name": "bob", "password": "correct horse battery staple ", "age": "42"}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Show() = %q, want %q", got, want)
+			}
+			// Input read ahead into the shared buffer is attributed to the prompt which consumed it
+			var gotInput []string
+			for _, e := range decodeRecording(t, recording.String()) {
+				if e.Kind == EventInput {
+					gotInput = append(gotInput, e.Data)
+				}
+			}
+			if !reflect.DeepEqual(gotInput, tt.wantInput) {
+				t.Errorf("recorded input = %q, want %q", gotInput, tt.wantInput)
+			}
+		})
+	}
+}
+
+func TestPrompt_SharedReaderOption(t *testing.T) {
+	withReader := WithReader(strings.NewReader("alice\nsecret\n"))
+	name := &Prompt{PromptMessage: "Name"}
+	password := &Prompt{PromptMessage: "Password", IsPassword: true}
+	name.SetOptions(withReader, WithWriter(new(bytes.Buffer)))
+	password.SetOptions(withReader, WithWriter(new(bytes.Buffer)))
+
+	if got, err := name.Show(); err != nil || got != "alice" {
+		t.Errorf("Show() = %v, %v, want %v", got, err, "alice")
+	}
+	if got, err := password.ShowSecret(); err != nil || string(got.Bytes()) != "secret" {
+		t.Errorf("ShowSecret() = %q, %v, want %q", got.Bytes(), err, "secret")
+	}
+}
+
+func TestPrompt_PasswordNotEchoedOnInvalid(t *testing.T) {
+	out := new(bytes.Buffer)
+	p := &Prompt{PromptMessage: "Password", IsPassword: true, InputValidatorRegex: regexp.MustCompile(`^\d+$`)}
+	p.SetOptions(WithReader(strings.NewReader("hunter2\n1234\n")), WithWriter(out))
+	if got, err := p.Show(); err != nil || got != "1234" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "1234")
+	}
+	if want := "\nInvalid Input\n\n"; !strings.Contains(out.String(), want) {
+		t.Errorf("Show() output = %q, want %q", out.String(), want)
+	}
+	if strings.Contains(out.String(), "hunter2") {
+		t.Errorf("Show() output = %q, rejected password was echoed", out.String())
+	}
+}
+
+func TestPrompt_PasswordPolicy(t *testing.T) {
+	policy := validation.PasswordPolicy{MinLength: 8, RequireDigit: true, DenyList: validation.MakeDenyList("password1")}
+	tests := []struct {
+		name      string
+		show      func(p *Prompt) (interface{}, error)
+		wantTexts []string
+	}{
+		{name: "Show", show: (*Prompt).Show},
+		{name: "ShowSecret", show: func(p *Prompt) (interface{}, error) {
+			s, err := p.ShowSecret()
+			return string(s.Bytes()), err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := &Prompt{PromptMessage: "New password", IsPassword: true, InputErrorValidatorFunc: policy.Validator()}
+			p.SetOptions(WithReader(strings.NewReader("short\npassword1\nsecure99\n")), WithWriter(out))
+			got, err := tt.show(p)
+			if err != nil || got != "secure99" {
+				t.Fatalf("Show() = %v, %v, want %v", got, err, "secure99")
+			}
+			for _, want := range []string{
+				"\nPassword must be at least 8 characters, must contain a digit\n\n",
+				"\nPassword is a commonly used password\n\n",
+			} {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Show() output = %q, want %q", out.String(), want)
+				}
+			}
+			if strings.Contains(out.String(), "short") || strings.Contains(out.String(), "password1") {
+				t.Errorf("Show() output = %q, rejected password was echoed", out.String())
+			}
+		})
+	}
+}
+
+func TestPrompt_StrengthMeter(t *testing.T) {
+	out := new(bytes.Buffer)
+	p := &Prompt{PromptMessage: "Password", IsPassword: true, PasswordMask: '*', StrengthMeter: validation.EstimateStrength}
+	p.SetOptions(WithLineEditor(), WithReader(strings.NewReader("aaaa\x15Tr0ub4dor&3\r")), WithWriter(out))
+	if _, err := p.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	for _, want := range []string{
+		"Password: \n\x1b[A",
+		"\x1b7\x1b[B\rStrength: [#----] Very weak\x1b[K\x1b8",
+		"\x1b7\x1b[B\rStrength: [####-] Strong\x1b[K\x1b8",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Show() output = %q, want %q", out.String(), want)
+		}
+	}
+	if want := "\x1b7\x1b[B\r\x1b[K\x1b8\n"; !strings.HasSuffix(out.String(), want) {
+		t.Errorf("Show() output = %q, want meter cleared with %q", out.String(), want)
+	}
+}
+
+func TestPrompt_StrengthAfterEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     rune
+		options  []Opt
+		wantText string
+	}{
+		{name: "Piped", wantText: "Password: \nStrength: Fair\n"},
+		{name: "Piped with mask", mask: '*', wantText: "Password: \nStrength: Fair\n"},
+		{name: "Line editor without mask", options: []Opt{WithLineEditor()}, wantText: "Password: \nStrength: Fair\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := &Prompt{PromptMessage: "Password", IsPassword: true, PasswordMask: tt.mask, StrengthMeter: validation.EstimateStrength}
+			p.SetOptions(append(tt.options, WithReader(strings.NewReader("hunter2\n")), WithWriter(out))...)
+			if got, err := p.Show(); err != nil || got != "hunter2" {
+				t.Fatalf("Show() = %v, %v, want %v", got, err, "hunter2")
+			}
+			if out.String() != tt.wantText {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
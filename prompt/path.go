@@ -0,0 +1,136 @@
+package prompt
+
+import (
+	"github.com/bchivari/go-cli-prompt/completion"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"github.com/bchivari/go-cli-prompt/validation"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PathKind restricts what a path prompt accepts
+type PathKind int
+
+const (
+	PathAny       PathKind = iota // Files or directories
+	PathFile                      // Files only
+	PathDirectory                 // Directories only
+)
+
+const pathExtensionSeparator = ", "
+
+// PathOptions configures a prompt made with MakePathPrompt
+type PathOptions struct {
+	Kind       PathKind // Restricts the prompt to files or directories
+	Extensions []string // If set, files must have one of these extensions, ie: ".yaml"
+	MustExist  bool     // If set, the path must already exist
+	Creatable  bool     // If set, a path which doesn't exist must be creatable: Its parent directory must exist and be writable
+}
+
+// MakePathPrompt returns a copy of p which asks for a file system path. Input gets Tab completion of paths, a
+// leading "~" and environment variables are expanded, the path is validated against opts and Show returns it as a
+// cleaned absolute path. Any InputValidatorFunc or OutputSerializerFunc already set on p also apply, and receive the
+// cleaned absolute path. The default InvalidInputMessage describes opts in the locale of the prompt
+func MakePathPrompt(p Prompt, opts PathOptions) Prompt {
+	if p.Completer == nil {
+		p.Completer = &completion.PathCompleter{
+			OnlyDirectories: opts.Kind == PathDirectory,
+			Extensions:      opts.Extensions,
+		}
+	}
+	p.pathOptions = &opts
+
+	pathValidator := func(s string) bool {
+		path, err := cleanPath(s)
+		return err == nil && opts.isValid(path)
+	}
+	if userValidator := p.InputValidatorFunc; userValidator != nil {
+		p.InputValidatorFunc = validation.MakeInputValidatorChain(pathValidator, func(s string) bool {
+			path, _ := cleanPath(s)
+			return userValidator(path)
+		})
+	} else {
+		p.InputValidatorFunc = pathValidator
+	}
+
+	userSerializer := p.OutputSerializerFunc
+	p.OutputSerializerFunc = func(s string) (interface{}, error) {
+		path, err := cleanPath(s)
+		if err != nil {
+			return nil, err
+		}
+		if userSerializer != nil {
+			return userSerializer(path)
+		}
+		return path, nil
+	}
+	return p
+}
+
+// cleanPath expands and returns the absolute, cleaned form of a typed path
+func cleanPath(s string) (string, error) {
+	expanded, err := completion.ExpandPath(s)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(expanded)
+}
+
+func (o PathOptions) isValid(path string) bool {
+	info, err := os.Stat(path)
+	if err == nil {
+		if info.IsDir() {
+			return o.Kind != PathFile
+		}
+		return o.Kind != PathDirectory && o.hasAllowedExtension(path)
+	}
+	if !os.IsNotExist(err) || o.MustExist {
+		return false
+	}
+	if o.Kind != PathDirectory && !o.hasAllowedExtension(path) {
+		return false
+	}
+	return !o.Creatable || isCreatable(path)
+}
+
+func (o PathOptions) hasAllowedExtension(path string) bool {
+	if len(o.Extensions) == 0 {
+		return true
+	}
+	for _, ext := range o.Extensions {
+		if strings.EqualFold(filepath.Ext(path), ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe builds the default invalid input message, ie: "Expected an existing file ending in .yaml, .yml"
+func (o PathOptions) describe(l i18n.Localizer) string {
+	ids := [3]i18n.MessageID{i18n.PathAny, i18n.PathFile, i18n.PathDirectory}
+	switch {
+	case o.MustExist:
+		ids = [3]i18n.MessageID{i18n.PathExistingAny, i18n.PathExistingFile, i18n.PathExistingDirectory}
+	case o.Creatable:
+		ids = [3]i18n.MessageID{i18n.PathCreatableAny, i18n.PathCreatableFile, i18n.PathCreatableDirectory}
+	}
+	msg := l.Message(ids[PathAny])
+	switch o.Kind {
+	case PathFile:
+		msg = l.Message(ids[PathFile])
+	case PathDirectory:
+		msg = l.Message(ids[PathDirectory])
+	}
+	if len(o.Extensions) > 0 && o.Kind != PathDirectory {
+		msg = l.Message(i18n.PathExtensions, msg, strings.Join(o.Extensions, pathExtensionSeparator))
+	}
+	return msg
+}
+
+// isCreatable reports if path could be created: Its parent must be a writable directory. Nothing is written
+func isCreatable(path string) bool {
+	parent := filepath.Dir(path)
+	info, err := os.Stat(parent)
+	return err == nil && info.IsDir() && isWritableDir(parent, info)
+}
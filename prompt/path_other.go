@@ -0,0 +1,13 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package prompt
+
+import (
+	"os"
+)
+
+// isWritableDir reports if dir isn't read-only; Access control lists aren't checked
+func isWritableDir(_ string, info os.FileInfo) bool {
+	return info.Mode().Perm()&0200 != 0
+}
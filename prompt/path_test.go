@@ -0,0 +1,132 @@
+package prompt
+
+import (
+	"bytes"
+	"github.com/bchivari/go-cli-prompt/completion"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMakePathPrompt(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	os.WriteFile(file, nil, 0600)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0600)
+
+	tests := []struct {
+		name     string
+		opts     PathOptions
+		input    string
+		want     string
+		wantText string
+	}{
+		{name: "Existing file", opts: PathOptions{MustExist: true}, input: file + "\n", want: file},
+		{name: "Cleaned", opts: PathOptions{MustExist: true}, input: dir + "/sub/../config.yaml\n", want: file},
+		{name: "Env var expanded", opts: PathOptions{MustExist: true}, input: "$PATH_PROMPT_DIR/config.yaml\n", want: file},
+		{name: "Missing file rejected", opts: PathOptions{MustExist: true}, input: "missing.yaml\n" + file + "\n", want: file, wantText: "Expected an existing path"},
+		{name: "Directory rejected for files", opts: PathOptions{Kind: PathFile}, input: dir + "\n" + file + "\n", want: file, wantText: "Expected a file"},
+		{name: "File rejected for directories", opts: PathOptions{Kind: PathDirectory, MustExist: true}, input: file + "\n" + dir + "\n", want: dir, wantText: "Expected an existing directory"},
+		{name: "Extension restricted", opts: PathOptions{Kind: PathFile, Extensions: []string{".yaml", ".yml"}}, input: dir + "/notes.txt\n" + file + "\n", want: file, wantText: "Expected a file ending in .yaml, .yml"},
+		{name: "Extension case insensitive", opts: PathOptions{Extensions: []string{".YAML"}}, input: file + "\n", want: file},
+		{name: "Creatable", opts: PathOptions{Creatable: true}, input: dir + "/out.json\n", want: filepath.Join(dir, "out.json")},
+		{name: "Missing parent not creatable", opts: PathOptions{Creatable: true}, input: dir + "/missing/out.json\n" + dir + "/out.json\n", want: filepath.Join(dir, "out.json"), wantText: "Expected a creatable path"},
+		{name: "File parent not creatable", opts: PathOptions{Creatable: true}, input: file + "/out.json\n" + dir + "/out.json\n", want: filepath.Join(dir, "out.json")},
+	}
+	t.Setenv("PATH_PROMPT_DIR", dir)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := MakePathPrompt(Prompt{PromptMessage: "Path"}, tt.opts)
+			p.SetOptions(WithReader(strings.NewReader(tt.input)), WithWriter(out))
+			got, err := p.Show()
+			if err != nil || got != tt.want {
+				t.Fatalf("Show() = %v, %v, want %v", got, err, tt.want)
+			}
+			if tt.wantText != "" && !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
+
+func TestMakePathPrompt_Hooks(t *testing.T) {
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	var validated []string
+	p := MakePathPrompt(Prompt{
+		PromptMessage:       "Output",
+		DefaultAsString:     "out.json",
+		InvalidInputMessage: "Pick another file",
+		InputValidatorFunc: func(s string) bool {
+			validated = append(validated, s)
+			return filepath.Base(s) != "reserved.json"
+		},
+		OutputSerializerFunc: func(s string) (interface{}, error) {
+			return filepath.Base(s), nil
+		},
+	}, PathOptions{Creatable: true})
+	out := new(bytes.Buffer)
+	p.SetOptions(WithReader(strings.NewReader(dir+"/reserved.json\n"+dir+"/./answer.json\n")), WithWriter(out))
+
+	got, err := p.Show()
+	if err != nil || got != "answer.json" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "answer.json")
+	}
+	if want := []string{filepath.Join(dir, "reserved.json"), filepath.Join(dir, "answer.json")}; strings.Join(validated, ",") != strings.Join(want, ",") {
+		t.Errorf("validator got %v, want cleaned absolute paths %v", validated, want)
+	}
+	if !strings.Contains(out.String(), "Pick another file") {
+		t.Errorf("Show() output = %q, want custom invalid input message", out.String())
+	}
+	if _, ok := p.Completer.(*completion.PathCompleter); !ok {
+		t.Errorf("Completer = %v, want path completer", p.Completer)
+	}
+
+	p = MakePathPrompt(Prompt{PromptMessage: "Output", DefaultAsString: "out.json"}, PathOptions{})
+	p.SetOptions(WithReader(strings.NewReader("\n")), WithWriter(new(bytes.Buffer)))
+	got, err = p.Show()
+	if want := filepath.Join(wd, "out.json"); err != nil || got != want {
+		t.Errorf("Show() = %v, %v, want default as absolute path %v", got, err, want)
+	}
+}
+
+func TestMakePathPrompt_Locale(t *testing.T) {
+	dir := t.TempDir()
+	out := new(bytes.Buffer)
+	p := MakePathPrompt(Prompt{PromptMessage: "Path"}, PathOptions{Kind: PathDirectory, MustExist: true})
+	p.SetOptions(WithLocale("de"), WithReader(strings.NewReader(dir+"/missing\n"+dir+"\n")), WithWriter(out))
+	if got, err := p.Show(); err != nil || got != dir {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, dir)
+	}
+	if want := "Erwartet wird ein vorhandenes Verzeichnis"; !strings.Contains(out.String(), want) {
+		t.Errorf("Show() output = %q, want %q", out.String(), want)
+	}
+}
+
+func Test_isCreatable(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	os.WriteFile(file, nil, 0600)
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "Parent exists", path: filepath.Join(dir, "out.json"), want: true},
+		{name: "Parent missing", path: filepath.Join(dir, "missing", "out.json"), want: false},
+		{name: "Parent is a file", path: filepath.Join(file, "out.json"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCreatable(tt.path); got != tt.want {
+				t.Errorf("isCreatable() = %v, want %v", got, tt.want)
+			}
+			if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+				t.Errorf("isCreatable() wrote to %v: %v", dir, entries)
+			}
+		})
+	}
+}
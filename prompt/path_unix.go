@@ -0,0 +1,14 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package prompt
+
+import (
+	"golang.org/x/sys/unix"
+	"os"
+)
+
+// isWritableDir reports if the process may create files in dir
+func isWritableDir(dir string, _ os.FileInfo) bool {
+	return unix.Access(dir, unix.W_OK) == nil
+}
@@ -0,0 +1,154 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultProgressWidth = 30
+	progressFilled       = "#"
+	progressEmpty        = "-"
+	progressTemplate     = "%v [%v%v] %3d%%"
+	progressLogTemplate  = "%v: %d%% (%v/%v)"
+	progressMaxPercent   = 100
+)
+
+// ProgressBar shows the progress of a slow operation towards Total. On a terminal it is redrawn on the current line;
+// Otherwise, or in accessible mode, the progress is logged when started and every LogInterval in which it changed. A
+// ProgressBar is stopped and erased when a Prompt or Select is displayed on the same Writer
+type ProgressBar struct {
+	Message     string        // Displayed before the bar; Change it with SetMessage while running
+	Total       int64         // The value at which the operation is complete
+	Width       int           // The number of columns of the bar, excluding the message and percentage. This will default to 30
+	Interval    time.Duration // The time between redraws on a terminal. This will default to 100ms
+	LogInterval time.Duration // The time between log lines if the Writer isn't a terminal. This will default to 10s
+	Writer      io.Writer     // Defaults to os.Stdout, as for a Prompt
+
+	mu       sync.Mutex
+	current  int64
+	logged   int64 // The value of the last log line, or -1 before the first
+	activity *activity
+}
+
+// Start displays the progress bar until Stop is called, with the Writer and intervals set at the time; Does nothing
+// if it is already running
+func (p *ProgressBar) Start() {
+	startActivity(&p.mu, &p.activity, func() *activity {
+		p.logged = -1
+		return &activity{
+			w:           getActivityWriter(p.Writer),
+			render:      p.render,
+			logLine:     p.logLine,
+			interval:    getActivityInterval(p.Interval, defaultActivityInterval),
+			logInterval: getActivityInterval(p.LogInterval, defaultActivityLogInterval),
+		}
+	})
+}
+
+// Set sets the progress to n, clamped to 0 and Total
+func (p *ProgressBar) Set(n int64) {
+	p.mu.Lock()
+	p.current = p.clamp(n)
+	p.mu.Unlock()
+}
+
+// Add adds n to the progress, ie: the bytes written by an io.Writer wrapped around the operation
+func (p *ProgressBar) Add(n int64) {
+	p.mu.Lock()
+	p.current = p.clamp(p.current + n)
+	p.mu.Unlock()
+}
+
+// SetMessage changes the message displayed before the bar
+func (p *ProgressBar) SetMessage(message string) {
+	p.mu.Lock()
+	p.Message = message
+	a := p.activity
+	p.mu.Unlock()
+	if a != nil {
+		a.refresh()
+	}
+}
+
+// Stop stops and erases the progress bar. If the Writer isn't a terminal, progress made since the last log line is
+// logged
+func (p *ProgressBar) Stop() {
+	p.StopWithMessage("")
+}
+
+// StopWithMessage stops and erases the progress bar, and writes message on its own line, ie: "✔ Downloaded"
+func (p *ProgressBar) StopWithMessage(message string) {
+	p.mu.Lock()
+	a := p.activity
+	p.mu.Unlock()
+	if a == nil {
+		return
+	}
+	if line := p.finalLogLine(a); line != "" {
+		if message != "" {
+			line += "\n" + message
+		}
+		message = line
+	}
+	a.halt(message)
+}
+
+// finalLogLine returns the log line of progress made since the last one, if the progress is logged
+func (p *ProgressBar) finalLogLine(a *activity) string {
+	a.mu.Lock()
+	logged := a.stop != nil && !a.tty
+	a.mu.Unlock()
+	if !logged {
+		return ""
+	}
+	return p.logLine(0)
+}
+
+func (p *ProgressBar) clamp(n int64) int64 {
+	switch {
+	case n < 0:
+		return 0
+	case p.Total > 0 && n > p.Total:
+		return p.Total
+	}
+	return n
+}
+
+// percent returns the progress in percent; Must be called with mu held
+func (p *ProgressBar) percent() int {
+	if p.Total <= 0 {
+		return 0
+	}
+	return int(p.current * progressMaxPercent / p.Total)
+}
+
+// render returns the message followed by the bar and the percentage, ie: "Downloading [###---] 50%"
+func (p *ProgressBar) render() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	width := p.Width
+	if width <= 0 {
+		width = defaultProgressWidth
+	}
+	filled := 0
+	if p.Total > 0 {
+		filled = int(p.current * int64(width) / p.Total)
+	}
+	return fmt.Sprintf(progressTemplate, p.Message, strings.Repeat(progressFilled, filled),
+		strings.Repeat(progressEmpty, width-filled), p.percent())
+}
+
+// logLine returns the progress, ie: "Downloading: 50% (512/1024)", or nothing if it didn't change since the last line
+func (p *ProgressBar) logLine(time.Duration) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.current == p.logged {
+		return ""
+	}
+	p.logged = p.current
+	return fmt.Sprintf(progressLogTemplate, p.Message, p.percent(), p.current, p.Total)
+}
@@ -1,10 +1,12 @@
 package prompt
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"github.com/bchivari/go-cli-prompt/completion"
+	"github.com/bchivari/go-cli-prompt/i18n"
 	"github.com/bchivari/go-cli-prompt/serialization"
 	"github.com/bchivari/go-cli-prompt/validation"
 	"golang.org/x/term"
@@ -12,22 +14,24 @@ import (
 	"os"
 	"regexp"
 	"strings"
-	"syscall"
+	"unicode/utf8"
 )
 
 const (
-	promptTemple               = "%v%v"
-	promptWithDefaultTemplate  = "%v [%v]%v"
-	errorTemplate              = "\n%v\n\n"
-	errorEchoInputTemplate     = "\n%v [%v]\n\n"
-	defaultInvalidInputMessage = "Invalid Input"
-	defaultPromptMessageDelim  = ": "
+	promptTemple              = "%v%v"
+	promptWithDefaultTemplate = "%v [%v]%v"
+	errorTemplate             = "\n%v\n\n"
+	errorEchoInputTemplate    = "\n%v [%v]\n\n"
+	defaultPromptMessageDelim = ": "
+	maskEraseSequence         = "\b \b"
 )
 
 var (
 	// Errors
 	errMissingKey       = errors.New("'MapKey' field is missing from one more more CliPrompts")
-	inputErrorTemplate  = "got irrecoverable input error: %v"
+	errInvalidInput     = errors.New("invalid input") // Failed InputValidatorFunc or InputValidatorRegex; Displayed as InvalidInputMessage
+	ErrInterrupted      = errors.New("interrupted")   // Returned (wrapped) by Show if Ctrl-C is pressed while the line editor is reading input
+	inputErrorTemplate  = "got irrecoverable input error: %w"
 	defaultOutputWriter = os.Stdout
 	defaultInputReader  = os.Stdin
 )
@@ -36,63 +40,123 @@ var (
 // If both DefaultAsString and OutputSerializerFunc are set, the default string
 // should be serializable via the OutputSerializerFunc
 type Prompt struct {
-	PromptMessage       string // The message prompt text displayed to user
-	AllowNil            bool   // If this prompt accepts nil as allowable input
-	IsPassword          bool   // If set, will suppress echoing of input to terminal
-	InvalidInputMessage string // Message displayed if InputValidatorFunc returns false, or nil is provided but not accepted by setting AllowNil
-	DefaultAsString     string // The default value if the user just hits enter without providing input
-	MapKey              string // If utilizing a PromptList, this string is used as a key in the map[string]interface{} returned by Show()
-
-	InputValidatorFunc   validation.InputValidator      // Function which validates the input string. If both InputValidatorFunc and InputValidatorRegex are provided both are tested, and both must pass for input to be valid
-	InputValidatorRegex  *regexp.Regexp                 // Regex used to validate the input
-	OutputSerializerFunc serialization.OutputSerializer // Function which converts the input string into a desired type returned as interface{}
+	PromptMessage       string                           // The message prompt text displayed to user
+	AllowNil            bool                             // If this prompt accepts nil as allowable input
+	IsPassword          bool                             // If set, will suppress echoing of input to terminal
+	IsMultiLine         bool                             // If set, input is read until a line containing only MultiLineSentinel, Ctrl-D or the end of input, and lines are joined with "\n"
+	PasswordMask        rune                             // If set, IsPassword input read from a terminal echoes this character for every typed character, ie: '*'
+	ConfirmPassword     bool                             // If set, IsPassword input is asked for twice and re-prompted if the entries don't match
+	StrengthMeter       func(string) validation.Strength // If set, IsPassword input shows the strength it returns, ie: validation.EstimateStrength. It is drawn below the input as it is typed if PasswordMask is set and the line editor is used, otherwise it is described on its own line once entered
+	IsEditor            bool                             // If set, input is entered by editing a temporary file holding DefaultAsString in $VISUAL or $EDITOR, launched when Enter is pressed; An empty file is empty input, never DefaultAsString
+	LiveValidation      bool                             // If set, input read with the line editor is validated as it is typed, showing whether it is valid below the input. Input is still validated, and OutputSerializerFunc only applied, when Enter is pressed
+	InvalidInputMessage string                           // Message displayed if InputValidatorFunc returns false, or nil is provided but not accepted by setting AllowNil. This will default to "Invalid Input" in the locale of the prompt
+	DefaultAsString     string                           // The default value if the user just hits enter without providing input
+	Help                string                           // Explains the expected input; Displayed when "?" is entered, or F1 is pressed in the line editor, without being treated as input
+	Placeholder         string                           // Example input displayed dimmed in the empty line editor on a terminal supporting colors; Never used as the value
+	MapKey              string                           // If utilizing a PromptList, this string is used as a key in the map[string]interface{} returned by Show()
+
+	InputValidatorFunc      validation.InputValidator      // Function which validates the input string. If both InputValidatorFunc and InputValidatorRegex are provided both are tested, and both must pass for input to be valid
+	InputValidatorRegex     *regexp.Regexp                 // Regex used to validate the input
+	InputErrorValidatorFunc validation.InputErrorValidator // Function which validates the input string after InputValidatorFunc and InputValidatorRegex, returning an error explaining why it is invalid. The error is displayed instead of InvalidInputMessage, ie: validation.PasswordPolicy
+	OutputSerializerFunc    serialization.OutputSerializer // Function which converts the input string into a desired type returned as interface{}
+	Completer               completion.Completer           // Offers completions when Tab is pressed in the line editor
 
 	PromptMessageDelim         string // The string/character displayed after the PromptMessage. This will default to ": "
 	SuppressTrimWhitespace     bool   // By default, both leading and trailing whitespace are trimmed from all input strings, unless IsPassword is set, before validation. Setting SuppressTrimWhitespace will disable this behavior
-	SuppressEchoInputOnInvalid bool   // By default, input that fails validation will echod back as part of the error message. Setting SuppressEchoInputOnInvalid will disable this behavior
+	SuppressEchoInputOnInvalid bool   // By default, input that fails validation will echod back as part of the error message, unless IsPassword is set. Setting SuppressEchoInputOnInvalid will disable this behavior
+	SuppressLineEditor         bool   // By default, input from a terminal is read in raw mode with a built-in line editor supporting cursor movement and kill/yank. Setting SuppressLineEditor reads cooked lines instead
+	HistoryID                  string // Key of this prompt's input history when set with SetOption(WithHistory). Defaults to MapKey
+	ConfirmPromptMessage       string // The message prompt text of the ConfirmPassword confirmation. This will default to "Confirm " followed by PromptMessage
+	MultiLineSentinel          string // The line which ends IsMultiLine input. If not set, only Ctrl-D or the end of input ends it
+	EditorFilePattern          string // Name pattern of the IsEditor temporary file, ie: "*.md" so the editor applies syntax highlighting. This will default to "*.txt"
 
 	outputWriter io.Writer // Advanced option so not exposed; Defaults to os.Stdout; Set with SetOption(WithWriter)
 	inputReader  io.Reader // Advanced option so not exposed; Defaults to os.Stdin; Set with SetOption(WithReader)
-	scanner      scanner   // bufio.Scanner wrapped in interface for Mocking / Testing
+	scanner      scanner   // Line reader wrapped in interface for Mocking / Testing
+	input        *sharedInput
+	recorder     *Recorder  // Advanced option so not exposed; Set with SetOption(WithRecorder)
+	renderer     Renderer   // Advanced option so not exposed; Defaults to DefaultRenderer; Set with SetOption(WithRenderer)
+	theme        *Theme     // Advanced option so not exposed; Output is plain if not set; Set with SetOption(WithTheme)
+	colorLevel   ColorLevel // Advanced option so not exposed; Detected from the output writer if not set; Set with SetOption(WithColorLevel)
+	locale       string     // Advanced option so not exposed; Locale of built-in messages; Defaults to the locale of the i18n package; Set with SetOption(WithLocale)
+
+	accessible    bool // Advanced option so not exposed; Also enabled by the ACCESSIBLE environment variable; Set with SetOption(WithAccessibleMode)
+	terminalWidth int  // Advanced option so not exposed; Columns text is wrapped at; Queried from the output terminal if not set; Set with SetOption(WithTerminalWidth)
+
+	answeredSummary bool          // Advanced option so not exposed; Set with SetOption(WithAnsweredSummary)
+	region          *screenRegion // Lines displayed since the prompt was first shown; Only counted if answeredSummary is set and the output is a terminal
+
+	history         *History     // Advanced option so not exposed; Set with SetOption(WithHistory)
+	forceLineEditor bool         // Advanced option so not exposed; Set with SetOption(WithLineEditor)
+	editor          *lineEditor  // Created on first use; Keeps the kill ring across re-prompts
+	editorContent   *string      // Text opened in the IsEditor editor; Keeps rejected text for the next attempt
+	pathOptions     *PathOptions // Set by MakePathPrompt; Describes the default InvalidInputMessage
 }
 
-// Show Displays a single Prompt and will return the supplied value. Blocks forever until valid input is received
+// Show Displays a single Prompt and will return the supplied value. Blocks until valid input is received; Closed input
+// is empty input, so io.EOF is returned only if it is neither answered by DefaultAsString nor accepted by AllowNil
 func (h *Prompt) Show() (interface{}, error) {
+	if err := h.checkRenderer(); err != nil {
+		return nil, err
+	}
+	h.recorder.recordPrompt(h)
+	ret, err := h.show()
+	if err == nil {
+		h.recorder.recordAnswer(h, ret)
+	}
+	return ret, err
+}
+
+func (h *Prompt) show() (interface{}, error) {
 	h.initializeScanner()
+	h.stopActivities()
+	h.beginRegion()
+	h.editorContent = nil
 	for {
 		h.showPrompt()
 		userInput, err := h.readInput()
+		// Closed input is empty input, so DefaultAsString or AllowNil still answers the prompt
+		closed := errors.Is(err, io.EOF)
 		// Irrecoverable Input Error
-		if err != nil {
-			return nil, fmt.Errorf(inputErrorTemplate, err.Error())
+		if err != nil && !closed {
+			return nil, fmt.Errorf(inputErrorTemplate, err)
+		}
+		if h.isHelpRequest(userInput) {
+			h.displayHelp()
+			continue
 		}
 		// Got input
 		if len(userInput) != 0 {
-			if h.isValidInput(userInput) {
+			validationErr := h.validate(userInput)
+			if validationErr == nil {
 				serializedResp, err := h.serializeIfRequired(userInput)
 				if err == nil && serializedResp != nil {
+					h.addToHistory(userInput)
+					h.displaySummary(userInput)
 					return serializedResp, nil
 				}
 			}
-			h.displayInvalidInputMessage(userInput)
+			h.displayValidationError(userInput, validationErr)
 		} else {
-			// Empty Input
-			if h.hasDefault() {
+			// Empty Input; An IsEditor prompt opens DefaultAsString, so empty input means the user cleared it
+			if h.hasDefault() && !h.IsEditor {
 				defaultSerialized, err := h.serializeIfRequired(h.DefaultAsString)
 				if err != nil {
-					fmt.Fprintf(h.getOutputWriter(), fmt.Sprintf("Default value cannot be serialized, This shouldn't happen. %v", err))
+					fmt.Fprint(h.getOutputWriter(), h.message(i18n.DefaultNotSerializable, err))
 				} else {
+					h.displaySummary(h.DefaultAsString)
 					return defaultSerialized, nil
 				}
 			}
-			if h.AllowNil && !h.hasDefault() {
+			if h.AllowNil && (!h.hasDefault() || h.IsEditor) {
+				h.displaySummary("")
 				return nil, nil
 			}
-			if h.shouldEchoInput() {
-				fmt.Fprintf(h.getOutputWriter(), errorEchoInputTemplate, h.getInvalidInputMessage(), "null")
-			} else {
-				fmt.Fprintf(h.getOutputWriter(), errorTemplate, h.getInvalidInputMessage())
+			if closed {
+				// Prompting again would never receive an answer
+				return nil, fmt.Errorf(inputErrorTemplate, err)
 			}
+			h.displayInvalidInputMessage(h.message(i18n.NullInput))
 			// Loop until we get valid input
 		}
 	}
@@ -107,6 +171,8 @@ func (h *Prompt) ShowWithContext(ctx context.Context) (interface{}, error) {
 	case err := <-errChan:
 		return nil, err
 	case <-ctx.Done():
+		// The abandoned read may still be blocked with the terminal in raw mode
+		restoreRawTerminals()
 		return nil, errors.New("call was canceled by context")
 	}
 }
@@ -143,7 +209,11 @@ func (c *PromptList) Show() (map[string]interface{}, error) {
 		if p.MapKey == "" {
 			return nil, errMissingKey
 		}
-		ret[p.MapKey], _ = p.Show()
+		resp, err := p.Show()
+		if err != nil {
+			return nil, err
+		}
+		ret[p.MapKey] = resp
 	}
 	return ret, nil
 }
@@ -157,6 +227,8 @@ func (c *PromptList) ShowWithContext(ctx context.Context) (map[string]interface{
 	case err := <-errChan:
 		return nil, err
 	case <-ctx.Done():
+		// The abandoned read may still be blocked with the terminal in raw mode
+		restoreRawTerminals()
 		return nil, errors.New("call was canceled by context")
 
 	}
@@ -183,11 +255,12 @@ func (h *Prompt) serializeIfRequired(input string) (interface{}, error) {
 }
 
 func (h *Prompt) showPrompt() {
-	if h.hasDefault() {
-		fmt.Fprintf(h.getOutputWriter(), promptWithDefaultTemplate, h.PromptMessage, h.DefaultAsString, h.getDelim())
-	} else {
-		fmt.Fprintf(h.getOutputWriter(), promptTemple, h.PromptMessage, h.getDelim())
-	}
+	fmt.Fprint(h.getOutputWriter(), h.getPromptText())
+}
+
+// getPromptText returns the rendered prompt, wrapped at word boundaries to the width of the terminal
+func (h *Prompt) getPromptText() string {
+	return wrapText(h.getRenderer().RenderPrompt(h.getPromptView()), h.getTerminalWidth())
 }
 
 func (h *Prompt) getDelim() string {
@@ -198,10 +271,14 @@ func (h *Prompt) getDelim() string {
 }
 
 func (h *Prompt) getOutputWriter() io.Writer {
-	if h.outputWriter != nil {
-		return h.outputWriter
+	w := h.getRawOutputWriter()
+	if h.answeredSummary {
+		w = &regionWriter{w: w, p: h}
 	}
-	return defaultOutputWriter
+	if h.recorder != nil {
+		return &recordingWriter{w: w, p: h}
+	}
+	return w
 }
 
 func (h *Prompt) getInputReader() io.Reader {
@@ -215,11 +292,14 @@ func (h *Prompt) getInvalidInputMessage() string {
 	if h.InvalidInputMessage != "" {
 		return h.InvalidInputMessage
 	}
-	return defaultInvalidInputMessage
+	if h.pathOptions != nil {
+		return h.pathOptions.describe(h.getLocalizer())
+	}
+	return h.message(i18n.InvalidInput)
 }
 
 func (h *Prompt) shouldEchoInput() bool {
-	if h.SuppressEchoInputOnInvalid {
+	if h.SuppressEchoInputOnInvalid || h.IsPassword {
 		return false
 	}
 	return true
@@ -236,6 +316,17 @@ func (h *Prompt) isValidInput(s string) bool {
 	return false
 }
 
+// validate returns nil if s is valid input, otherwise the error of InputErrorValidatorFunc or errInvalidInput
+func (h *Prompt) validate(s string) error {
+	if !h.isValidInput(s) {
+		return errInvalidInput
+	}
+	if h.InputErrorValidatorFunc != nil {
+		return h.InputErrorValidatorFunc(s)
+	}
+	return nil
+}
+
 func (h *Prompt) validateAgainstRegexIfProvided(s string) bool {
 	if h.InputValidatorRegex != nil {
 		return h.InputValidatorRegex.MatchString(s)
@@ -254,64 +345,258 @@ func (h *Prompt) readInput() (string, error) {
 	if !h.IsPassword {
 		return h.readRegularInput()
 	}
+	password, err := h.readConfirmedPassword()
+	defer wipe(password)
+	return string(password), err
+}
 
+// readConfirmedPassword reads a password, and if ConfirmPassword is set re-reads it until both entries match
+func (h *Prompt) readConfirmedPassword() ([]byte, error) {
+	for {
+		password, err := h.readPasswordLine()
+		if err == nil {
+			h.displayStrength(password)
+		}
+		if err != nil || !h.ConfirmPassword {
+			return password, err
+		}
+		fmt.Fprint(h.getOutputWriter(), h.getConfirmPromptText())
+		confirmation, err := h.readPasswordLine()
+		match := bytes.Equal(confirmation, password)
+		wipe(confirmation)
+		if err != nil {
+			wipe(password)
+			return nil, err
+		}
+		if match {
+			return password, nil
+		}
+		wipe(password)
+		fmt.Fprint(h.getOutputWriter(), h.renderErrorMessage(h.message(i18n.PasswordMismatch)))
+		h.showPrompt()
+	}
+}
+
+func (h *Prompt) readPasswordLine() ([]byte, error) {
 	defer func() {
 		// Print blank line after input is received since a non-echoing password reader won't show newline
 		fmt.Fprintln(h.getOutputWriter(), "")
 	}()
-	return h.readPasswordInput()
+	password, err := h.readPasswordInput()
+	if err == nil {
+		h.recorder.recordPasswordInput(h)
+	}
+	return password, err
 }
 
-func (h *Prompt) readPasswordInput() (string, error) {
-	if h.inputReader != nil {
-		return h.readPasswordFromIoReader()
+func (h *Prompt) getConfirmPromptText() string {
+	message := h.message(i18n.ConfirmPrompt, h.PromptMessage)
+	if h.ConfirmPromptMessage != "" {
+		message = h.ConfirmPromptMessage
 	}
-	return h.readPasswordFromStdin()
+	return h.getRenderer().RenderPrompt(PromptView{Message: h.style(h.getTheme().Label, message), Delim: h.getDelim(), Password: true})
 }
 
-func (h *Prompt) readPasswordFromStdin() (string, error) {
-	bytePassword, err := term.ReadPassword(int(syscall.Stdin))
-	fmt.Fprintln(h.getOutputWriter(), "")
-	if err != nil {
-		return "", fmt.Errorf(errorTemplate, h.getInvalidInputMessage())
+func (h *Prompt) readPasswordInput() ([]byte, error) {
+	if h.readsMaskedPassword() {
+		return h.readMaskedPassword(h.PasswordMask)
 	}
-	return string(bytePassword), nil
+	if _, ok := h.getInputTerminal(); ok {
+		// Read from the shared buffer, not the terminal, as it may hold type-ahead read by the line editor
+		return h.readMaskedPassword(0)
+	}
+	return h.readPasswordFromLine()
+}
+
+// readPasswordFromLine reads a password which isn't typed on a terminal, ie: piped, from the same lines as regular
+// input. The whole line is the password, including spaces
+func (h *Prompt) readPasswordFromLine() ([]byte, error) {
+	defer h.recordInput()
+	if !h.scanner.Scan() {
+		if err := h.scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	return []byte(h.scanner.Text()), nil
+}
+
+// readsMaskedPassword reports if the password is read with readMaskedPassword, echoing PasswordMask
+func (h *Prompt) readsMaskedPassword() bool {
+	return h.PasswordMask != 0 && h.shouldUseLineEditor()
 }
 
-func (h *Prompt) readPasswordFromIoReader() (string, error) {
-	var password []byte
-	if file, ok := h.inputReader.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
-		password, err := term.ReadPassword(int(file.Fd()))
+// readMaskedPassword reads a password in raw mode, echoing mask for every typed character; Nothing is echoed if mask
+// is 0
+func (h *Prompt) readMaskedPassword(mask rune) ([]byte, error) {
+	if f, ok := h.getInputTerminal(); ok {
+		restore, err := makeRaw(f)
 		if err != nil {
-			return "", fmt.Errorf(errorTemplate, h.getInvalidInputMessage())
+			return nil, err
 		}
-		return string(password), nil
+		defer restore()
+	}
+	in := h.getInput()
+	defer h.recordInput()
+	var meter *strengthMeter
+	if mask != 0 {
+		meter = h.newStrengthMeter()
 	}
+	meter.reserve()
+	defer meter.clear()
+	password := make([]byte, 0, secretInitialCapacity)
+	for {
+		meter.update(password)
+		k, err := readKey(in)
+		h.recordInput()
+		if err == io.EOF && len(password) > 0 {
+			return password, nil
+		}
+		if err != nil {
+			wipe(password)
+			return nil, err
+		}
+		switch k.code {
+		case keyEnter:
+			return password, nil
+		case keyInterrupt:
+			wipe(password)
+			fmt.Fprint(h.getOutputWriter(), "^C")
+			return nil, ErrInterrupted
+		case keyEOF:
+			if len(password) == 0 {
+				return nil, io.EOF
+			}
+		case keyRune:
+			password = appendSecretRune(password, k.r)
+			if mask != 0 {
+				fmt.Fprint(h.getOutputWriter(), string(mask))
+			}
+		case keyBackspace:
+			if len(password) > 0 {
+				_, size := utf8.DecodeLastRune(password)
+				wipe(password[len(password)-size:])
+				password = password[:len(password)-size]
+				fmt.Fprint(h.getOutputWriter(), getMaskErase(mask, 1))
+			}
+		case keyKillToStart:
+			fmt.Fprint(h.getOutputWriter(), getMaskErase(mask, utf8.RuneCount(password)))
+			wipe(password)
+			password = password[:0]
+		}
+	}
+}
 
-	if _, err := fmt.Fscanf(h.inputReader, "%s\n", &password); err != nil {
-		return "", fmt.Errorf(errorTemplate, h.getInvalidInputMessage())
+// getMaskErase returns the sequence erasing n masks, each as many columns wide as mask; Empty if mask is 0
+func getMaskErase(mask rune, n int) string {
+	if mask == 0 {
+		return ""
 	}
-	return string(password), nil
+	return strings.Repeat(maskEraseSequence, n*runeWidth(mask))
 }
 
 func (h *Prompt) readRegularInput() (string, error) {
-	h.scanner.Scan()
+	var (
+		input string
+		err   error
+	)
+	switch {
+	case h.IsMultiLine:
+		input, err = h.readMultiLineInput()
+	case h.IsEditor:
+		input, err = h.readEditorInput()
+	default:
+		input, err = h.readLine(h.getPromptText())
+	}
 	if h.SuppressTrimWhitespace {
-		return h.scanner.Text(), h.scanner.Err()
+		return input, err
 	}
-	return strings.TrimSpace(h.scanner.Text()), h.scanner.Err()
+	return strings.TrimSpace(input), err
+}
+
+// readLine reads a single line of input, with the line editor if enabled. promptText is redrawn by the line editor
+func (h *Prompt) readLine(promptText string) (string, error) {
+	defer h.recordInput()
+	if h.shouldUseLineEditor() {
+		return h.readEditedInput(promptText)
+	}
+	if !h.scanner.Scan() && h.scanner.Err() == nil {
+		// Input was closed; Re-prompting would never receive an answer
+		return "", io.EOF
+	}
+	h.countEchoedLine(promptText, h.scanner.Text())
+	return h.scanner.Text(), h.scanner.Err()
 }
 
 func (h *Prompt) displayInvalidInputMessage(response string) {
-	if h.shouldEchoInput() {
-		fmt.Fprintf(h.getOutputWriter(), errorEchoInputTemplate, h.getInvalidInputMessage(), response)
+	h.displayErrorMessage(response, h.getInvalidInputMessage())
+}
+
+// displayValidationError displays the error returned by InputErrorValidatorFunc, or InvalidInputMessage for other
+// validation failures
+func (h *Prompt) displayValidationError(response string, err error) {
+	if err == nil || err == errInvalidInput {
+		h.displayInvalidInputMessage(response)
 		return
 	}
-	fmt.Fprintf(h.getOutputWriter(), errorTemplate, h.getInvalidInputMessage())
+	h.displayErrorMessage(response, err.Error())
+}
+
+func (h *Prompt) displayErrorMessage(response string, message string) {
+	fmt.Fprint(h.getOutputWriter(), h.renderError(response, message))
+}
+
+func (h *Prompt) readEditedInput(promptText string) (string, error) {
+	if f, ok := h.getInputTerminal(); ok {
+		restore, err := makeRaw(f)
+		if err != nil {
+			return "", err
+		}
+		defer restore()
+	}
+	if in := h.getInput(); h.editor == nil || h.editor.in != in {
+		h.editor = newLineEditor(in, h.getOutputWriter())
+	}
+	h.editor.completer = h.Completer
+	h.editor.localizer = h.getLocalizer()
+	h.editor.keyRead = h.recordInput
+	h.editor.width = h.getTerminalWidth
+	h.editor.status = nil
+	h.editor.help, h.editor.placeholder = "", ""
+	if !h.IsMultiLine && !h.IsEditor {
+		if h.LiveValidation {
+			h.editor.status = h.getLiveStatus
+		}
+		h.editor.help = h.renderHelp()
+		h.editor.placeholder, h.editor.placeholderWidth = h.renderPlaceholder()
+	}
+	e := h.editor
+	defer addLiveLine(&liveLine{w: h.getRawOutputWriter(), lock: &e.mu, clear: e.clearLine, redraw: e.redraw})()
+	return e.readLine(promptText[strings.LastIndex(promptText, "\n")+1:], h.getHistoryEntries())
+}
+
+func (h *Prompt) shouldUseLineEditor() bool {
+	if h.SuppressLineEditor || h.isAccessible() {
+		return false
+	}
+	if h.forceLineEditor {
+		return true
+	}
+	_, ok := h.getInputTerminal()
+	return ok
+}
+
+// getInputTerminal returns the input as an *os.File if it is a terminal
+func (h *Prompt) getInputTerminal() (*os.File, bool) {
+	if file, ok := h.getInputReader().(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		return file, true
+	}
+	return nil, false
 }
 
 func (h *Prompt) initializeScanner() {
+	in := h.getInput()
 	if h.scanner == nil {
-		h.scanner = newDefaultScanner(bufio.NewScanner(h.getInputReader()))
+		h.scanner = newLineScanner(in)
 	}
 }
@@ -0,0 +1,148 @@
+//go:build go1.18
+// +build go1.18
+
+package prompt
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+const fuzzPromptMarker = "\x00prompt\x00"
+
+var fuzzValidatorRegex = regexp.MustCompile(`^[a-z ]+$`)
+
+// fuzzRenderer renders every prompt as fuzzPromptMarker, which neither the prompt message nor the default can produce
+type fuzzRenderer struct {
+	DefaultRenderer
+}
+
+func (fuzzRenderer) RenderPrompt(PromptView) string {
+	return fuzzPromptMarker
+}
+
+// countingWriter counts how many times the prompt was rendered. Input must not be echoed, as it could contain the
+// marker
+type countingWriter struct {
+	bytes.Buffer
+	prompts int
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	w.prompts += strings.Count(string(b), fuzzPromptMarker)
+	return w.Buffer.Write(b)
+}
+
+func fuzzValidatorFunc(s string) bool {
+	return utf8.ValidString(s) && len(s) < 32
+}
+
+func addFuzzSeeds(f *testing.F) {
+	f.Add([]byte("bob\n"), false, false, false, "")
+	f.Add([]byte("  bob  \n"), false, true, false, "")
+	f.Add([]byte("\n\nbob"), false, false, true, "")
+	f.Add([]byte("BOB\r\nbob\r\n"), false, false, false, "default")
+	f.Add([]byte("pass word\n"), true, false, false, "")
+	f.Add([]byte("\xff\xfe\n"), false, false, false, "")
+	f.Add([]byte(""), true, false, true, "")
+	f.Add([]byte("Fuzz\n"), false, false, false, "")
+	f.Add([]byte(fuzzPromptMarker+"\n"), false, false, false, fuzzPromptMarker)
+}
+
+func FuzzPrompt_Show(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, input []byte, isPassword bool, suppressTrim bool, allowNil bool, defaultValue string) {
+		w := &countingWriter{}
+		p := &Prompt{
+			PromptMessage:              "Fuzz",
+			IsPassword:                 isPassword,
+			SuppressTrimWhitespace:     suppressTrim,
+			SuppressEchoInputOnInvalid: true,
+			AllowNil:                   allowNil,
+			DefaultAsString:            defaultValue,
+			InputValidatorRegex:        fuzzValidatorRegex,
+			InputValidatorFunc:         fuzzValidatorFunc,
+		}
+		p.SetOptions(WithReader(bytes.NewReader(input)), WithWriter(w), WithRenderer(fuzzRenderer{}))
+
+		got, err := p.Show()
+
+		// Every prompt rendered must be answered by a line of input, except the last one which may see the end of input
+		lines := bytes.Count(input, []byte("\n"))
+		if len(input) > 0 && input[len(input)-1] != '\n' {
+			lines++
+		}
+		if w.prompts > lines+1 {
+			t.Fatalf("rendered %d prompts for %d lines of input", w.prompts, lines)
+		}
+		if err != nil {
+			if got != nil {
+				t.Fatalf("Show() = %q with error %v", got, err)
+			}
+			return
+		}
+		if got == nil {
+			if !allowNil || defaultValue != "" {
+				t.Fatalf("Show() = nil, AllowNil = %v, default = %q", allowNil, defaultValue)
+			}
+			return
+		}
+		s := got.(string)
+		if s == defaultValue {
+			return
+		}
+		if !fuzzValidatorRegex.MatchString(s) || !fuzzValidatorFunc(s) {
+			t.Fatalf("Show() = %q which fails validation", s)
+		}
+		if !suppressTrim && !isPassword && s != strings.TrimSpace(s) {
+			t.Fatalf("Show() = %q which was not trimmed", s)
+		}
+		if !bytes.Contains(input, []byte(s)) {
+			t.Fatalf("Show() = %q which is not part of the input", s)
+		}
+	})
+}
+
+func FuzzPromptList_Show(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, input []byte, isPassword bool, suppressTrim bool, allowNil bool, defaultValue string) {
+		l := MakePromptList(
+			Prompt{
+				PromptMessage:       "Fuzz",
+				MapKey:              "first",
+				AllowNil:            allowNil,
+				DefaultAsString:     defaultValue,
+				InputValidatorRegex: fuzzValidatorRegex,
+			},
+			Prompt{
+				PromptMessage:          "Fuzz",
+				MapKey:                 "second",
+				IsPassword:             isPassword,
+				SuppressTrimWhitespace: suppressTrim,
+				InputValidatorFunc:     fuzzValidatorFunc,
+			},
+		)
+		l.SetOptions(WithReader(bytes.NewReader(input)), WithWriter(new(bytes.Buffer)))
+
+		got, err := l.Show()
+
+		if err != nil {
+			if got != nil {
+				t.Fatalf("Show() = %v with error %v", got, err)
+			}
+			return
+		}
+		if len(got) != 2 {
+			t.Fatalf("Show() = %v, want an answer per prompt", got)
+		}
+		if s, ok := got["first"].(string); ok && s != defaultValue && !fuzzValidatorRegex.MatchString(s) {
+			t.Fatalf("Show() first = %q which fails validation", s)
+		}
+		if s, ok := got["second"].(string); !ok || !fuzzValidatorFunc(s) {
+			t.Fatalf("Show() second = %q which fails validation", got["second"])
+		}
+	})
+}
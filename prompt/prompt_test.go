@@ -266,6 +266,72 @@ func TestPrompt_Display(t *testing.T) {
 			wantErr:               true,
 			wantOutputWriterRegex: nil,
 		},
+		{
+			name: "Input closed before answer",
+			fields: fields{
+				PromptMessage:              promptMessage1,
+				AllowNil:                   false,
+				IsPassword:                 false,
+				InvalidInputMessage:        "",
+				DefaultAsString:            "",
+				InputValidatorFunc:         nil,
+				InputValidatorRegex:        nil,
+				OutputSerializerFunc:       nil,
+				MapKey:                     "",
+				PromptMessageDelim:         "",
+				SuppressTrimWhitespace:     false,
+				SuppressEchoInputOnInvalid: false,
+				outputWriter:               new(bytes.Buffer),
+				inputReader:                bytes.NewBufferString(""),
+			},
+			want:                  nil,
+			wantErr:               true,
+			wantOutputWriterRegex: nil,
+		},
+		{
+			name: "Input closed with default",
+			fields: fields{
+				PromptMessage:              promptMessage1,
+				AllowNil:                   false,
+				IsPassword:                 false,
+				InvalidInputMessage:        "",
+				DefaultAsString:            "Bobby",
+				InputValidatorFunc:         nil,
+				InputValidatorRegex:        nil,
+				OutputSerializerFunc:       nil,
+				MapKey:                     "",
+				PromptMessageDelim:         "",
+				SuppressTrimWhitespace:     false,
+				SuppressEchoInputOnInvalid: false,
+				outputWriter:               new(bytes.Buffer),
+				inputReader:                bytes.NewBufferString(""),
+			},
+			want:                  "Bobby",
+			wantErr:               false,
+			wantOutputWriterRegex: nil,
+		},
+		{
+			name: "Input closed with AllowNil",
+			fields: fields{
+				PromptMessage:              promptMessage1,
+				AllowNil:                   true,
+				IsPassword:                 false,
+				InvalidInputMessage:        "",
+				DefaultAsString:            "",
+				InputValidatorFunc:         nil,
+				InputValidatorRegex:        nil,
+				OutputSerializerFunc:       nil,
+				MapKey:                     "",
+				PromptMessageDelim:         "",
+				SuppressTrimWhitespace:     false,
+				SuppressEchoInputOnInvalid: false,
+				outputWriter:               new(bytes.Buffer),
+				inputReader:                bytes.NewBufferString(""),
+			},
+			want:                  nil,
+			wantErr:               false,
+			wantOutputWriterRegex: nil,
+		},
 		{
 			name: "Test using default Stdout",
 			fields: fields{
@@ -0,0 +1,56 @@
+package prompt
+
+import (
+	"golang.org/x/term"
+	"os"
+	"sync"
+)
+
+// rawTerminals holds the terminals put in raw mode to read keys, most recent last, so a canceled ShowWithContext can
+// restore them while its abandoned read is still blocked
+var rawTerminals = struct {
+	sync.Mutex
+	entries []*rawTerminal
+}{}
+
+// rawTerminal is a terminal in raw mode and the state it is restored to
+type rawTerminal struct {
+	fd    int
+	state *term.State
+}
+
+// makeRaw puts the terminal f in raw mode and returns a func restoring it, which does nothing if restoreRawTerminals
+// already restored it
+func makeRaw(f *os.File) (func(), error) {
+	fd := int(f.Fd())
+	rawTerminals.Lock()
+	defer rawTerminals.Unlock()
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	t := &rawTerminal{fd: fd, state: state}
+	rawTerminals.entries = append(rawTerminals.entries, t)
+	return func() {
+		rawTerminals.Lock()
+		defer rawTerminals.Unlock()
+		for i, e := range rawTerminals.entries {
+			if e == t {
+				rawTerminals.entries = append(rawTerminals.entries[:i], rawTerminals.entries[i+1:]...)
+				term.Restore(t.fd, t.state)
+				return
+			}
+		}
+	}, nil
+}
+
+// restoreRawTerminals restores every terminal still in raw mode, most recent first, so the state saved by the
+// outermost makeRaw is restored last
+func restoreRawTerminals() {
+	rawTerminals.Lock()
+	defer rawTerminals.Unlock()
+	for i := len(rawTerminals.entries) - 1; i >= 0; i-- {
+		term.Restore(rawTerminals.entries[i].fd, rawTerminals.entries[i].state)
+	}
+	rawTerminals.entries = nil
+}
@@ -0,0 +1,134 @@
+package prompt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Kinds of RecordedEvent
+const (
+	EventPrompt = "prompt" // A prompt started; Data holds the PromptMessage
+	EventOutput = "output" // Data holds text written to the prompt's io.Writer
+	EventInput  = "input"  // Data holds raw input consumed by the prompt, a line or the keys of a line at a time
+	EventAnswer = "answer" // Data holds the value returned by Show, formatted with %v
+)
+
+const redactedData = "<redacted>"
+
+// RecordedEvent is a single entry of a session recording; Recordings are stored as one JSON encoded event per line
+type RecordedEvent struct {
+	Offset   time.Duration `json:"offset"` // Time since the recording started, in nanoseconds
+	Kind     string        `json:"kind"`
+	Key      string        `json:"key,omitempty"` // MapKey of the prompt which produced the event
+	Data     string        `json:"data"`
+	Redacted bool          `json:"redacted,omitempty"` // Set if Data was withheld because the prompt IsPassword
+}
+
+// Recorder captures every prompt rendered, the raw input and its timing, and the final answers of the prompts it
+// is attached to with WithRecorder. Input and answers of IsPassword prompts are redacted
+type Recorder struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	start time.Time
+	file  *os.File
+	err   error
+}
+
+// NewRecorder returns a Recorder which writes the recording to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{
+		enc:   json.NewEncoder(w),
+		start: time.Now(),
+	}
+}
+
+// CreateRecording creates (or truncates) the named file and returns a Recorder writing to it; Close must be called when done
+func CreateRecording(name string) (*Recorder, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	r := NewRecorder(f)
+	r.file = f
+	return r, nil
+}
+
+// Err returns the first error encountered while writing the recording
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Close closes the recording file if it was opened by CreateRecording, and returns the first error encountered
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file != nil {
+		if err := r.file.Close(); err != nil && r.err == nil {
+			r.err = err
+		}
+		r.file = nil
+	}
+	return r.err
+}
+
+// WithRecorder returns an option func which records the prompt's session to r
+func WithRecorder(r *Recorder) Opt {
+	return func(p *Prompt) error {
+		p.recorder = r
+		return nil
+	}
+}
+
+func (r *Recorder) record(kind string, key string, data string, redacted bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if redacted {
+		data = redactedData
+	}
+	e := RecordedEvent{
+		Offset:   time.Since(r.start),
+		Kind:     kind,
+		Key:      key,
+		Data:     data,
+		Redacted: redacted,
+	}
+	if err := r.enc.Encode(e); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+func (r *Recorder) recordPrompt(p *Prompt) {
+	r.record(EventPrompt, p.MapKey, p.PromptMessage, false)
+}
+
+func (r *Recorder) recordPasswordInput(p *Prompt) {
+	r.record(EventInput, p.MapKey, "", true)
+}
+
+func (r *Recorder) recordAnswer(p *Prompt, answer interface{}) {
+	if answer == nil {
+		r.record(EventAnswer, p.MapKey, "", p.IsPassword)
+		return
+	}
+	r.record(EventAnswer, p.MapKey, fmt.Sprintf("%v", answer), p.IsPassword)
+}
+
+// recordingWriter records everything written through it as EventOutput
+type recordingWriter struct {
+	w io.Writer
+	p *Prompt
+}
+
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	rw.p.recorder.record(EventOutput, rw.p.MapKey, string(b), false)
+	return rw.w.Write(b)
+}
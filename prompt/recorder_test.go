@@ -0,0 +1,173 @@
+package prompt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func makeRecordingTestList(nameInput string, passwordInput string) *PromptList {
+	return MakePromptList(
+		Prompt{
+			PromptMessage:       "Name",
+			MapKey:              "name",
+			InputValidatorRegex: regexp.MustCompile(`^[A-Z]`),
+			outputWriter:        new(bytes.Buffer),
+			inputReader:         bytes.NewBufferString(nameInput),
+		},
+		Prompt{
+			PromptMessage: "Password",
+			MapKey:        "password",
+			IsPassword:    true,
+			outputWriter:  new(bytes.Buffer),
+			inputReader:   bytes.NewBufferString(passwordInput),
+		},
+	)
+}
+
+func decodeRecording(t *testing.T, recording string) []RecordedEvent {
+	var events []RecordedEvent
+	for _, line := range strings.Split(strings.TrimSpace(recording), "\n") {
+		var e RecordedEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid recording line %q: %v", line, err)
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestRecorder(t *testing.T) {
+	var recording bytes.Buffer
+	l := makeRecordingTestList("bob\nBob\n", "hunter2\n")
+	l.SetOptions(WithRecorder(NewRecorder(&recording)))
+
+	if _, err := l.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if strings.Contains(recording.String(), "hunter2") {
+		t.Errorf("recording contains password: %v", recording.String())
+	}
+
+	var got []string
+	var lastOffset int64
+	for _, e := range decodeRecording(t, recording.String()) {
+		got = append(got, fmt.Sprintf("%v/%v/%q/%v", e.Kind, e.Key, e.Data, e.Redacted))
+		if int64(e.Offset) < lastOffset {
+			t.Errorf("event offsets are not increasing: %v", e)
+		}
+		lastOffset = int64(e.Offset)
+	}
+	want := []string{
+		`prompt/name/"Name"/false`,
+		`output/name/"Name: "/false`,
+		`input/name/"bob\n"/false`,
+		`output/name/"\nInvalid Input [bob]\n\n"/false`,
+		`output/name/"Name: "/false`,
+		`input/name/"Bob\n"/false`,
+		`answer/name/"Bob"/false`,
+		`prompt/password/"Password"/false`,
+		`output/password/"Password: "/false`,
+		`input/password/"<redacted>"/true`,
+		`output/password/"\n"/false`,
+		`answer/password/"<redacted>"/true`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recorded events = %v, want %v", got, want)
+	}
+}
+
+func TestRecorder_SharedInput(t *testing.T) {
+	keys := []string{`name/"b"`, `name/"o"`, `name/"b"`, `name/"\n"`, `password/"<redacted>"`,
+		`city/"P"`, `city/"a"`, `city/"r"`, `city/"i"`, `city/"s"`, `city/"\n"`}
+	tests := []struct {
+		name    string
+		mask    rune
+		options []Opt
+		want    []string
+	}{
+		{name: "Lines", want: []string{`name/"bob\n"`, `password/"<redacted>"`, `city/"Paris\n"`}},
+		{name: "Line editor", options: []Opt{WithLineEditor()}, want: keys},
+		{name: "Line editor with mask", mask: '*', options: []Opt{WithLineEditor()}, want: keys},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var recording bytes.Buffer
+			l := MakePromptList(
+				Prompt{PromptMessage: "Name", MapKey: "name"},
+				Prompt{PromptMessage: "Password", MapKey: "password", IsPassword: true, PasswordMask: tt.mask},
+				Prompt{PromptMessage: "City", MapKey: "city"},
+			)
+			l.SetOptions(append(tt.options, WithReader(strings.NewReader("bob\nhunter2\nParis\n")),
+				WithWriter(new(bytes.Buffer)), WithRecorder(NewRecorder(&recording)))...)
+
+			if _, err := l.Show(); err != nil {
+				t.Fatalf("Show() error = %v", err)
+			}
+			if strings.Contains(recording.String(), "hunter2") {
+				t.Errorf("recording contains password: %v", recording.String())
+			}
+			var got []string
+			for _, e := range decodeRecording(t, recording.String()) {
+				if e.Kind == EventInput {
+					got = append(got, fmt.Sprintf("%v/%q", e.Key, e.Data))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("recorded input = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecorder_SelectKeys(t *testing.T) {
+	var recording bytes.Buffer
+	s := &Select{Prompt: Prompt{PromptMessage: "Color", MapKey: "color"}, Options: MakeOptions("red", "green", "blue")}
+	s.SetOptions(WithLineEditor(), WithReader(strings.NewReader("\x1b[Bb\r")), WithWriter(new(bytes.Buffer)),
+		WithRecorder(NewRecorder(&recording)))
+	if got, err := s.Show(); err != nil || got != "blue" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "blue")
+	}
+	var got []string
+	for _, e := range decodeRecording(t, recording.String()) {
+		if e.Kind == EventInput {
+			got = append(got, e.Data)
+		}
+	}
+	// Every key is recorded as it is read, so replaying in real time reproduces the typing
+	if want := []string{"\x1b[B", "b", "\r"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("recorded input = %q, want %q", got, want)
+	}
+}
+
+func TestCreateRecording(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "session.jsonl")
+	r, err := CreateRecording(name)
+	if err != nil {
+		t.Fatalf("CreateRecording() error = %v", err)
+	}
+	p := &Prompt{PromptMessage: "Name", outputWriter: new(bytes.Buffer), inputReader: bytes.NewBufferString("Bob\n")}
+	p.SetOptions(WithRecorder(r))
+	if _, err := p.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	rep, err := LoadRecording(name)
+	if err != nil {
+		t.Fatalf("LoadRecording() error = %v", err)
+	}
+	if got := rep.Answers()[""]; got != "Bob" {
+		t.Errorf("Answers() = %v, want %v", got, "Bob")
+	}
+	if _, err := CreateRecording(filepath.Join(name, "not-a-dir")); err == nil {
+		t.Errorf("CreateRecording() error = nil, want error")
+	}
+}
@@ -0,0 +1,239 @@
+package prompt
+
+import (
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"io"
+	"strings"
+	"text/template"
+)
+
+const (
+	multiLineHintTemplate = "(%v)\n"
+	passwordSummaryMask   = "********" // Fixed length so the summary doesn't reveal the password's length
+)
+
+// PromptView describes a prompt line for a Renderer
+type PromptView struct {
+	Message   string // PromptMessage
+	Default   string // DefaultAsString; Empty if there is none or it isn't displayed, ie: for IsEditor prompts
+	Hint      string // Instructions displayed with the prompt, ie: "Enter to launch editor"; Empty if there are none
+	Delim     string // PromptMessageDelim, or ": " if not set
+	MultiLine bool   // Set if input starts on the line after the prompt, ie: for IsMultiLine prompts
+	Password  bool   // Set for IsPassword prompts
+}
+
+// ErrorView describes an error message for a Renderer
+type ErrorView struct {
+	Message   string // InvalidInputMessage, the error of InputErrorValidatorFunc, or another error
+	Input     string // The rejected input; "null", in the locale of the prompt, if it was empty
+	EchoInput bool   // Set if Input should be displayed; Unset for IsPassword prompts or if SuppressEchoInputOnInvalid is set
+}
+
+// SummaryView describes an accepted answer for a Renderer
+type SummaryView struct {
+	PromptView
+	Answer  string // The input as entered, or DefaultAsString; Masked for IsPassword prompts
+	Compact bool   // Set if the prompt and its error messages were cleared, so the summary replaces them; See WithAnsweredSummary
+}
+
+// Renderer formats the text displayed by a Prompt; Set with SetOption(WithRenderer). Embed DefaultRenderer to
+// override only some of the methods
+type Renderer interface {
+	RenderPrompt(v PromptView) string   // Returns the prompt line with its default and hint, ie: "Name [Bob]: "
+	RenderError(v ErrorView) string     // Returns an error message with the echoed input, ie: "\nInvalid Input [x]\n\n"
+	RenderSummary(v SummaryView) string // Returns the text displayed once an answer is accepted; Nothing is displayed if empty
+}
+
+// DefaultRenderer is the Renderer used if none is set
+type DefaultRenderer struct{}
+
+// RenderPrompt returns "Message [Default] [Hint]: ", or "Message: (Hint)\n" for MultiLine prompts
+func (DefaultRenderer) RenderPrompt(v PromptView) string {
+	message := v.Message
+	if v.Default != "" {
+		message = fmt.Sprintf(promptWithDefaultTemplate, message, v.Default, "")
+	}
+	if v.MultiLine {
+		text := fmt.Sprintf(promptTemple, message, v.Delim)
+		if v.Hint != "" {
+			text += fmt.Sprintf(multiLineHintTemplate, v.Hint)
+		}
+		return text
+	}
+	if v.Hint != "" {
+		return fmt.Sprintf(promptWithDefaultTemplate, message, v.Hint, v.Delim)
+	}
+	return fmt.Sprintf(promptTemple, message, v.Delim)
+}
+
+// RenderError returns "\nMessage [Input]\n\n", or "\nMessage\n\n" if the input isn't echoed
+func (DefaultRenderer) RenderError(v ErrorView) string {
+	if v.EchoInput {
+		return fmt.Sprintf(errorEchoInputTemplate, v.Message, v.Input)
+	}
+	return fmt.Sprintf(errorTemplate, v.Message)
+}
+
+// RenderSummary returns "✔ Message: Answer\n" if the prompt was cleared, otherwise nothing as the answer stays visible
+// after the prompt
+func (DefaultRenderer) RenderSummary(v SummaryView) string {
+	if !v.Compact {
+		return ""
+	}
+	return fmt.Sprintf(summaryTemplate, summaryMark, v.Message, v.Delim, v.Answer)
+}
+
+// TemplateRenderer is a Renderer executing text/template templates, for changing the displayed strings without
+// implementing a Renderer. Prompt is executed with a PromptView, Error with an ErrorView and Summary with a
+// SummaryView. A nil template falls back to DefaultRenderer. Templates which fail to execute with an empty view, ie:
+// referring to a missing field, are rejected by MakeTemplateRenderer and make Show return the error; A template
+// failing only for some views falls back to DefaultRenderer for those
+type TemplateRenderer struct {
+	Prompt  *template.Template
+	Error   *template.Template
+	Summary *template.Template
+}
+
+// MakeTemplateRenderer is a helper function used to parse the templates of a TemplateRenderer. Empty strings fall
+// back to DefaultRenderer, ie: MakeTemplateRenderer("{{.Message}} > ", "", "")
+func MakeTemplateRenderer(prompt, errorMessage, summary string) (*TemplateRenderer, error) {
+	r := &TemplateRenderer{}
+	for _, t := range []struct {
+		name string
+		text string
+		dst  **template.Template
+	}{
+		{"prompt", prompt, &r.Prompt},
+		{"error", errorMessage, &r.Error},
+		{"summary", summary, &r.Summary},
+	} {
+		if t.text == "" {
+			continue
+		}
+		parsed, err := template.New(t.name).Parse(t.text)
+		if err != nil {
+			return nil, err
+		}
+		*t.dst = parsed
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// validate executes every template with an empty view, and returns the first error
+func (r *TemplateRenderer) validate() error {
+	for _, t := range []struct {
+		tmpl *template.Template
+		view interface{}
+	}{
+		{r.Prompt, PromptView{}},
+		{r.Error, ErrorView{}},
+		{r.Summary, SummaryView{}},
+	} {
+		if t.tmpl == nil {
+			continue
+		}
+		if err := t.tmpl.Execute(io.Discard, t.view); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RenderPrompt executes Prompt with v
+func (r *TemplateRenderer) RenderPrompt(v PromptView) string {
+	if text, ok := execute(r.Prompt, v); ok {
+		return text
+	}
+	return DefaultRenderer{}.RenderPrompt(v)
+}
+
+// RenderError executes Error with v
+func (r *TemplateRenderer) RenderError(v ErrorView) string {
+	if text, ok := execute(r.Error, v); ok {
+		return text
+	}
+	return DefaultRenderer{}.RenderError(v)
+}
+
+// RenderSummary executes Summary with v
+func (r *TemplateRenderer) RenderSummary(v SummaryView) string {
+	if text, ok := execute(r.Summary, v); ok {
+		return text
+	}
+	return DefaultRenderer{}.RenderSummary(v)
+}
+
+func execute(t *template.Template, data interface{}) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// checkRenderer returns the error of a TemplateRenderer whose templates fail to execute, so Show fails before anything
+// is displayed rather than falling back silently
+func (h *Prompt) checkRenderer() error {
+	if r, ok := h.renderer.(*TemplateRenderer); ok && r != nil {
+		return r.validate()
+	}
+	return nil
+}
+
+func (h *Prompt) getRenderer() Renderer {
+	if h.renderer != nil {
+		return h.renderer
+	}
+	return DefaultRenderer{}
+}
+
+// getPromptView describes the prompt line of h
+func (h *Prompt) getPromptView() PromptView {
+	theme := h.getTheme()
+	v := PromptView{Message: h.style(theme.Label, h.PromptMessage), Delim: h.getDelim(), MultiLine: h.IsMultiLine, Password: h.IsPassword}
+	switch {
+	case h.IsMultiLine:
+		v.Hint = h.style(theme.Hint, h.getMultiLineHint())
+	case h.IsEditor:
+		v.Hint = h.style(theme.Hint, h.message(i18n.EditorHint))
+	case h.hasDefault():
+		v.Default = h.style(theme.Default, h.DefaultAsString)
+	}
+	return v
+}
+
+// displaySummary clears the prompt if set with SetOption(WithAnsweredSummary), and displays the summary of the
+// accepted answer if the Renderer returns one. In accessible mode the answer is always confirmed in words instead
+func (h *Prompt) displaySummary(answer string) {
+	if h.IsPassword && answer != "" {
+		answer = passwordSummaryMask
+	}
+	if h.isAccessible() {
+		h.displayAccessibleAnswer(answer)
+		return
+	}
+	compact := h.clearRegion()
+	view := SummaryView{PromptView: h.getPromptView(), Answer: h.style(h.getTheme().Summary, answer), Compact: compact}
+	if text := h.getRenderer().RenderSummary(view); text != "" {
+		fmt.Fprint(h.getOutputWriter(), text)
+	}
+}
+
+// renderError formats message for display, echoing response unless the input shouldn't be echoed. Like the prompt,
+// it is wrapped at the terminal width so every row is counted by the screenRegion
+func (h *Prompt) renderError(response string, message string) string {
+	view := ErrorView{Message: h.style(h.getTheme().Error, message), Input: response, EchoInput: h.shouldEchoInput()}
+	return wrapText(h.getRenderer().RenderError(view), h.getTerminalWidth())
+}
+
+// renderErrorMessage formats message for display without echoing input, wrapped like renderError
+func (h *Prompt) renderErrorMessage(message string) string {
+	return wrapText(h.getRenderer().RenderError(ErrorView{Message: h.style(h.getTheme().Error, message)}), h.getTerminalWidth())
+}
@@ -0,0 +1,148 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestDefaultRenderer_RenderPrompt(t *testing.T) {
+	tests := []struct {
+		name string
+		view PromptView
+		want string
+	}{
+		{name: "Message", view: PromptView{Message: "Name", Delim: ": "}, want: "Name: "},
+		{name: "Default", view: PromptView{Message: "Name", Default: "Bob", Delim: ": "}, want: "Name [Bob]: "},
+		{name: "Hint", view: PromptView{Message: "Body", Hint: "Enter to launch editor", Delim: ": "}, want: "Body [Enter to launch editor]: "},
+		{name: "MultiLine", view: PromptView{Message: "Body", Hint: "End with Ctrl-D", Delim: ": ", MultiLine: true}, want: "Body: (End with Ctrl-D)\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (DefaultRenderer{}).RenderPrompt(tt.view); got != tt.want {
+				t.Errorf("RenderPrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultRenderer_RenderError(t *testing.T) {
+	r := DefaultRenderer{}
+	if got := r.RenderError(ErrorView{Message: "Invalid Input", Input: "x", EchoInput: true}); got != "\nInvalid Input [x]\n\n" {
+		t.Errorf("RenderError() = %q, want echoed input", got)
+	}
+	if got := r.RenderError(ErrorView{Message: "Invalid Input", Input: "x"}); got != "\nInvalid Input\n\n" {
+		t.Errorf("RenderError() = %q, want no echoed input", got)
+	}
+}
+
+func TestDefaultRenderer_RenderSummary(t *testing.T) {
+	v := SummaryView{PromptView: PromptView{Message: "Name", Delim: ": "}, Answer: "Bob"}
+	if got := (DefaultRenderer{}).RenderSummary(v); got != "" {
+		t.Errorf("RenderSummary() = %q, want nothing", got)
+	}
+	v.Compact = true
+	if got := (DefaultRenderer{}).RenderSummary(v); got != "✔ Name: Bob\n" {
+		t.Errorf("RenderSummary() = %q, want summary line", got)
+	}
+}
+
+func TestMakeTemplateRenderer(t *testing.T) {
+	if _, err := MakeTemplateRenderer("{{.Message", "", ""); err == nil {
+		t.Errorf("MakeTemplateRenderer() error = nil, want parse error")
+	}
+	if _, err := MakeTemplateRenderer("", "", "{{.Missing}}"); err == nil {
+		t.Errorf("MakeTemplateRenderer() error = nil, want execution error")
+	}
+	if _, err := MakeTemplateRenderer("", "", "{{index .Answer 0}}"); err == nil {
+		t.Errorf("MakeTemplateRenderer() error = nil, want execution error for an empty view")
+	}
+	r, err := MakeTemplateRenderer("", "", "{{.Answer}}")
+	if err != nil {
+		t.Fatalf("MakeTemplateRenderer() error = %v", err)
+	}
+	if r.Prompt != nil || r.Error != nil {
+		t.Errorf("MakeTemplateRenderer() parsed empty templates")
+	}
+}
+
+func TestPrompt_InvalidTemplateRenderer(t *testing.T) {
+	renderer := &TemplateRenderer{Summary: template.Must(template.New("summary").Parse("{{.Missing}}"))}
+	tests := []struct {
+		name string
+		show func(out io.Writer) error
+	}{
+		{name: "Prompt", show: func(out io.Writer) error {
+			p := &Prompt{PromptMessage: "Name"}
+			p.SetOptions(WithRenderer(renderer), WithReader(strings.NewReader("Bob\n")), WithWriter(out))
+			_, err := p.Show()
+			return err
+		}},
+		{name: "Secret", show: func(out io.Writer) error {
+			p := &Prompt{PromptMessage: "Password", IsPassword: true}
+			p.SetOptions(WithRenderer(renderer), WithReader(strings.NewReader("hunter2\n")), WithWriter(out))
+			_, err := p.ShowSecret()
+			return err
+		}},
+		{name: "Select", show: func(out io.Writer) error {
+			s := &Select{Prompt: Prompt{PromptMessage: "Color"}, Options: MakeOptions("red")}
+			s.SetOptions(WithRenderer(renderer), WithReader(strings.NewReader("1\n")), WithWriter(out))
+			_, err := s.Show()
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			if err := tt.show(out); err == nil || !strings.Contains(err.Error(), "Missing") {
+				t.Errorf("Show() error = %v, want template execution error", err)
+			}
+			if out.Len() != 0 {
+				t.Errorf("Show() output = %q, want nothing displayed", out.String())
+			}
+		})
+	}
+}
+
+func TestPrompt_Renderer(t *testing.T) {
+	renderer, err := MakeTemplateRenderer(
+		"{{.Message}}{{if .Default}} ({{.Default}}){{end}} > ",
+		"! {{.Message}}{{if .EchoInput}}: {{.Input}}{{end}}\n",
+		"{{.Message}} = {{.Answer}}\n",
+	)
+	if err != nil {
+		t.Fatalf("MakeTemplateRenderer() error = %v", err)
+	}
+	tests := []struct {
+		name     string
+		prompt   Prompt
+		input    string
+		wantText string
+	}{
+		{name: "Default", prompt: Prompt{PromptMessage: "Name", DefaultAsString: "Bob"}, input: "\n", wantText: "Name (Bob) > Name = Bob\n"},
+		{name: "Invalid", prompt: Prompt{PromptMessage: "Age", InputValidatorFunc: func(s string) bool { return s != "x" }}, input: "x\n42\n", wantText: "Age > ! Invalid Input: x\nAge > Age = 42\n"},
+		{name: "Error validator", prompt: Prompt{PromptMessage: "Age", InputErrorValidatorFunc: func(s string) error {
+			if s == "x" {
+				return errors.New("Not a number")
+			}
+			return nil
+		}}, input: "x\n42\n", wantText: "! Not a number: x\n"},
+		{name: "Password", prompt: Prompt{PromptMessage: "Password", IsPassword: true}, input: "\nhunter2\n", wantText: "Password > \n! Invalid Input\nPassword > \nPassword = ********\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := tt.prompt
+			p.SetOptions(WithRenderer(renderer), WithReader(strings.NewReader(tt.input)), WithWriter(out))
+			if _, err := p.Show(); err != nil {
+				t.Fatalf("Show() error = %v", err)
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+}
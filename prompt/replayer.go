@@ -0,0 +1,155 @@
+package prompt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRedactedInput is returned when replay reaches password input which was redacted and no secret was supplied
+	ErrRedactedInput = errors.New("recording contains redacted input; supply it with SetSecret")
+)
+
+// Replayer drives a Prompt or PromptList with the input captured in a session recording. It is attached with
+// WithReplayer and serves the recorded input chunk by chunk, so prompts read it exactly as they did when recorded
+type Replayer struct {
+	RealTime bool // If set, input is delivered with the delays captured in the recording instead of immediately
+
+	mu         sync.Mutex
+	events     []RecordedEvent
+	next       int
+	pending    string
+	lastOffset time.Duration
+	secrets    map[string]string
+}
+
+// NewReplayer reads a recording written by a Recorder from r
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	rep := &Replayer{secrets: make(map[string]string)}
+	dec := json.NewDecoder(r)
+	for {
+		var e RecordedEvent
+		if err := dec.Decode(&e); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid recording: %v", err)
+		}
+		rep.events = append(rep.events, e)
+	}
+	return rep, nil
+}
+
+// LoadRecording reads the named recording file written by a Recorder
+func LoadRecording(name string) (*Replayer, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewReplayer(f)
+}
+
+// WithReplayer returns an option func which reads the prompt's input from the recording held by r
+func WithReplayer(r *Replayer) Opt {
+	return func(p *Prompt) error {
+		p.inputReader = r
+		return nil
+	}
+}
+
+// SetSecret supplies the value to replay in place of the redacted password input of the prompt with the given MapKey
+func (r *Replayer) SetSecret(key string, secret string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.secrets[key] = secret
+}
+
+// Events returns every event of the recording
+func (r *Replayer) Events() []RecordedEvent {
+	return r.events
+}
+
+// Output returns everything the recorded prompts wrote
+func (r *Replayer) Output() string {
+	var sb strings.Builder
+	for _, e := range r.events {
+		if e.Kind == EventOutput {
+			sb.WriteString(e.Data)
+		}
+	}
+	return sb.String()
+}
+
+// Answers returns the recorded answers keyed by MapKey; Redacted answers are omitted
+func (r *Replayer) Answers() map[string]string {
+	ret := make(map[string]string)
+	for _, e := range r.events {
+		if e.Kind == EventAnswer && !e.Redacted {
+			ret[e.Key] = e.Data
+		}
+	}
+	return ret
+}
+
+// Verify checks the answers returned by a replayed PromptList against the recorded ones, which makes a recording
+// usable as a regression test
+func (r *Replayer) Verify(answers map[string]interface{}) error {
+	for key, want := range r.Answers() {
+		got, ok := answers[key]
+		if !ok {
+			return fmt.Errorf("answer %q is missing, recorded %q", key, want)
+		}
+		if got == nil {
+			got = ""
+		}
+		if fmt.Sprintf("%v", got) != want {
+			return fmt.Errorf("answer %q is %q, recorded %q", key, fmt.Sprintf("%v", got), want)
+		}
+	}
+	return nil
+}
+
+// Read implements io.Reader by serving the recorded input
+func (r *Replayer) Read(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == "" {
+		e, ok := r.nextInput()
+		if !ok {
+			return 0, io.EOF
+		}
+		if r.RealTime {
+			time.Sleep(e.Offset - r.lastOffset)
+		}
+		r.lastOffset = e.Offset
+		if e.Redacted {
+			secret, ok := r.secrets[e.Key]
+			if !ok {
+				return 0, fmt.Errorf("%w: %q", ErrRedactedInput, e.Key)
+			}
+			r.pending = secret + "\n"
+		} else {
+			r.pending = e.Data
+		}
+	}
+	n := copy(b, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+func (r *Replayer) nextInput() (RecordedEvent, bool) {
+	for r.next < len(r.events) {
+		e := r.events[r.next]
+		r.next++
+		if e.Kind == EventInput {
+			return e, true
+		}
+	}
+	return RecordedEvent{}, false
+}
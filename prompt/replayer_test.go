@@ -0,0 +1,104 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func recordTestSession(t *testing.T) string {
+	var recording bytes.Buffer
+	l := makeRecordingTestList("bob\nBob\n", "hunter2\n")
+	l.SetOptions(WithRecorder(NewRecorder(&recording)))
+	if _, err := l.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	return recording.String()
+}
+
+func TestReplayer(t *testing.T) {
+	rep, err := NewReplayer(strings.NewReader(recordTestSession(t)))
+	if err != nil {
+		t.Fatalf("NewReplayer() error = %v", err)
+	}
+	rep.SetSecret("password", "hunter2")
+
+	l := makeRecordingTestList("", "")
+	out := new(bytes.Buffer)
+	l.SetOptions(WithReplayer(rep), WithWriter(out))
+	got, err := l.Show()
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if got["password"] != "hunter2" {
+		t.Errorf("Show() password = %v, want %v", got["password"], "hunter2")
+	}
+	if err := rep.Verify(got); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if out.String() != rep.Output() {
+		t.Errorf("replayed output = %q, recorded %q", out.String(), rep.Output())
+	}
+}
+
+func TestReplayer_MissingSecret(t *testing.T) {
+	rep, _ := NewReplayer(strings.NewReader(recordTestSession(t)))
+
+	l := makeRecordingTestList("", "")
+	l.SetOptions(WithReplayer(rep))
+	if _, err := l.Show(); err == nil {
+		t.Errorf("Show() error = nil, want error")
+	}
+
+	rep, _ = NewReplayer(strings.NewReader(recordTestSession(t)))
+	buf := make([]byte, 64)
+	for _, want := range []string{"bob\n", "Bob\n"} {
+		if n, err := rep.Read(buf); err != nil || string(buf[:n]) != want {
+			t.Fatalf("Read() = %q, %v, want %q", buf[:n], err, want)
+		}
+	}
+	if _, err := rep.Read(buf); !errors.Is(err, ErrRedactedInput) {
+		t.Errorf("Read() error = %v, want %v", err, ErrRedactedInput)
+	}
+}
+
+func TestReplayer_Verify(t *testing.T) {
+	rep, _ := NewReplayer(strings.NewReader(recordTestSession(t)))
+	tests := []struct {
+		name    string
+		answers map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "Matches",
+			answers: map[string]interface{}{"name": "Bob", "password": "anything"},
+		},
+		{
+			name:    "Different",
+			answers: map[string]interface{}{"name": "Alice"},
+			wantErr: true,
+		},
+		{
+			name:    "Missing",
+			answers: map[string]interface{}{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rep.Verify(tt.answers); (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewReplayer_InvalidRecording(t *testing.T) {
+	if _, err := NewReplayer(strings.NewReader("not json")); err == nil {
+		t.Errorf("NewReplayer() error = nil, want error")
+	}
+	if _, err := LoadRecording("does-not-exist.jsonl"); err == nil {
+		t.Errorf("LoadRecording() error = nil, want error")
+	}
+}
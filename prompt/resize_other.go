@@ -0,0 +1,10 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package prompt
+
+// watchResize does nothing as there is no resize signal, and reports so; The width is then queried every time it is
+// needed
+func watchResize(resized func()) bool {
+	return false
+}
@@ -0,0 +1,22 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package prompt
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize calls resized whenever the terminal is resized, and reports that it will
+func watchResize(resized func()) bool {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGWINCH)
+	go func() {
+		for range c {
+			resized()
+		}
+	}()
+	return true
+}
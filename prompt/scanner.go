@@ -1,6 +1,10 @@
 package prompt
 
-import "bufio"
+import (
+	"bufio"
+	"io"
+	"strings"
+)
 
 // scanner interface to enable mocking
 type scanner interface {
@@ -9,26 +13,38 @@ type scanner interface {
 	Text() string
 }
 
-type defaultScanner struct {
-	scanner *bufio.Scanner
+// lineScanner reads lines from the input shared between prompts. Unlike bufio.Scanner it never reads past the end
+// of the line, so the next prompt sharing the input starts where this one stopped. Like bufio.ScanLines, a trailing
+// "\r" is dropped so CRLF input is accepted
+type lineScanner struct {
+	r    *bufio.Reader
+	text string
+	err  error
 }
 
-func newDefaultScanner(s *bufio.Scanner) *defaultScanner {
-	i := new(defaultScanner)
-	i.scanner = s
-	return i
+func newLineScanner(r *bufio.Reader) *lineScanner {
+	return &lineScanner{r: r}
 }
 
-func (s *defaultScanner) Scan() bool {
-	return s.scanner.Scan()
+func (s *lineScanner) Scan() bool {
+	line, err := s.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		s.text = ""
+		if err != io.EOF {
+			s.err = err
+		}
+		return false
+	}
+	s.text = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	return true
 }
 
-func (s *defaultScanner) Err() error {
-	return s.scanner.Err()
+func (s *lineScanner) Err() error {
+	return s.err
 }
 
-func (s *defaultScanner) Text() string {
-	return s.scanner.Text()
+func (s *lineScanner) Text() string {
+	return s.text
 }
 
 // mockScanner to enable testing. Emits elements in Fifo until empty then returns error
@@ -0,0 +1,219 @@
+package prompt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	jsonSchemaDraft      = "https://json-schema.org/draft/2020-12/schema"
+	docTableNone         = "-"
+	docTableRequired     = "yes"
+	docTableOptional     = "no"
+	manTableStart        = ".TS\nallbox;\nl l l l l.\n"
+	manTableEnd          = ".TE\n"
+	markdownTableStart   = "| Key | Prompt | Default | Pattern | Required |\n| --- | --- | --- | --- | --- |\n"
+	duplicateKeyTemplate = "%w: %q"
+)
+
+var (
+	errDuplicateKey = errors.New("'MapKey' field is used by more than one prompt")
+	// re2OnlySyntax matches RE2 constructs which ECMA-262, the regular expression dialect of JSON Schema, lacks or
+	// reads differently: inline flags, Python style named groups, \A, \z, \Q...\E and POSIX classes
+	re2OnlySyntax = regexp.MustCompile(`\(\?[imsU-]+[:)]|\(\?P<|\\[AzQ]|\[\[:`)
+)
+
+// jsonSchemaProperty describes a single answer in the generated JSON Schema
+type jsonSchemaProperty struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+	WriteOnly   bool   `json:"writeOnly,omitempty"`
+}
+
+// jsonSchemaProperties keeps properties in PromptList order, which encoding/json would not do for a map
+type jsonSchemaProperties struct {
+	keys   []string
+	values map[string]jsonSchemaProperty
+}
+
+func (p jsonSchemaProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range p.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(p.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+type jsonSchema struct {
+	Schema               string               `json:"$schema"`
+	Type                 string               `json:"type"`
+	Properties           jsonSchemaProperties `json:"properties"`
+	Required             []string             `json:"required,omitempty"`
+	AdditionalProperties bool                 `json:"additionalProperties"`
+}
+
+// JSONSchema returns a JSON Schema document describing an answer file for the PromptList.
+// Keys come from MapKey, patterns from InputValidatorRegex, defaults from DefaultAsString and
+// every prompt which does not set AllowNil is listed as required. Patterns are Go RE2 syntax and aren't translated to
+// the ECMA-262 syntax of JSON Schema; Most patterns mean the same in both, but patterns using RE2 only constructs, ie:
+// inline flags such as (?i), are omitted. MapKeys must be unique
+func (c *PromptList) JSONSchema() ([]byte, error) {
+	if err := c.checkKeys(); err != nil {
+		return nil, err
+	}
+	schema := jsonSchema{
+		Schema: jsonSchemaDraft,
+		Type:   "object",
+		Properties: jsonSchemaProperties{
+			values: make(map[string]jsonSchemaProperty),
+		},
+	}
+	for _, p := range *c {
+		schema.Properties.keys = append(schema.Properties.keys, p.MapKey)
+		schema.Properties.values[p.MapKey] = jsonSchemaProperty{
+			Type:        "string",
+			Description: p.PromptMessage,
+			Default:     p.DefaultAsString,
+			Pattern:     p.getSchemaPattern(),
+			WriteOnly:   p.IsPassword,
+		}
+		if !p.AllowNil {
+			schema.Required = append(schema.Required, p.MapKey)
+		}
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// MarkdownTable returns a Markdown table documenting every prompt in the PromptList
+func (c *PromptList) MarkdownTable() (string, error) {
+	if err := c.checkKeys(); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString(markdownTableStart)
+	for _, p := range *c {
+		fmt.Fprintf(&sb, "| %v | %v | %v | %v | %v |\n",
+			markdownCode(p.MapKey),
+			markdownEscape(p.PromptMessage),
+			markdownCode(p.DefaultAsString),
+			markdownCode(p.getPattern()),
+			p.getRequiredAsString())
+	}
+	return sb.String(), nil
+}
+
+// ManTable returns a tbl(1) table, suitable for embedding in a man page, documenting every prompt in the PromptList
+func (c *PromptList) ManTable() (string, error) {
+	if err := c.checkKeys(); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString(manTableStart)
+	sb.WriteString("Key\tPrompt\tDefault\tPattern\tRequired\n")
+	for _, p := range *c {
+		fmt.Fprintf(&sb, "%v\t%v\t%v\t%v\t%v\n",
+			roffEscape(p.MapKey),
+			roffEscape(p.PromptMessage),
+			roffEscape(orNone(p.DefaultAsString)),
+			roffEscape(orNone(p.getPattern())),
+			p.getRequiredAsString())
+	}
+	sb.WriteString(manTableEnd)
+	return sb.String(), nil
+}
+
+func (h *Prompt) getPattern() string {
+	if h.InputValidatorRegex != nil {
+		return h.InputValidatorRegex.String()
+	}
+	return ""
+}
+
+// getSchemaPattern returns the pattern of InputValidatorRegex if it means the same in ECMA-262, otherwise ""
+func (h *Prompt) getSchemaPattern() string {
+	pattern := h.getPattern()
+	if re2OnlySyntax.MatchString(pattern) {
+		return ""
+	}
+	return pattern
+}
+
+// checkKeys returns an error if a prompt has no MapKey or shares it with another prompt
+func (c *PromptList) checkKeys() error {
+	seen := make(map[string]bool)
+	for _, p := range *c {
+		if p.MapKey == "" {
+			return errMissingKey
+		}
+		if seen[p.MapKey] {
+			return fmt.Errorf(duplicateKeyTemplate, errDuplicateKey, p.MapKey)
+		}
+		seen[p.MapKey] = true
+	}
+	return nil
+}
+
+func (h *Prompt) getRequiredAsString() string {
+	if h.AllowNil {
+		return docTableOptional
+	}
+	return docTableRequired
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return docTableNone
+	}
+	return s
+}
+
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// markdownCode returns s in a code span delimited by more backticks than s contains in a row, so backticks in s
+// don't end it early
+func markdownCode(s string) string {
+	if s == "" {
+		return docTableNone
+	}
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	s = markdownEscape(s)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		// A single leading and trailing space is stripped from code spans, so backticks at the ends stay content
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+func roffEscape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "\t", " ", "\n", " ").Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
@@ -0,0 +1,155 @@
+package prompt
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func makeSchemaTestList() *PromptList {
+	return MakePromptList(
+		Prompt{
+			PromptMessage:       "Host | IP",
+			MapKey:              "host",
+			DefaultAsString:     "localhost",
+			InputValidatorRegex: regexp.MustCompile(`^[a-z|.]+$`),
+		},
+		Prompt{
+			PromptMessage: ".Password",
+			MapKey:        "password",
+			IsPassword:    true,
+			AllowNil:      true,
+		},
+	)
+}
+
+func TestPromptList_JSONSchema(t *testing.T) {
+	got, err := makeSchemaTestList().JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	if strings.Index(string(got), `"host"`) > strings.Index(string(got), `"password"`) {
+		t.Errorf("JSONSchema() properties are not in PromptList order: %s", got)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(got, &schema); err != nil {
+		t.Fatalf("JSONSchema() returned invalid JSON: %v", err)
+	}
+	want := map[string]interface{}{
+		"$schema": jsonSchemaDraft,
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"host": map[string]interface{}{
+				"type":        "string",
+				"description": "Host | IP",
+				"default":     "localhost",
+				"pattern":     `^[a-z|.]+$`,
+			},
+			"password": map[string]interface{}{
+				"type":        "string",
+				"description": ".Password",
+				"writeOnly":   true,
+			},
+		},
+		"required":             []interface{}{"host"},
+		"additionalProperties": false,
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Errorf("JSONSchema() = %v, want %v", schema, want)
+	}
+}
+
+func TestPromptList_MarkdownTable(t *testing.T) {
+	got, err := makeSchemaTestList().MarkdownTable()
+	if err != nil {
+		t.Fatalf("MarkdownTable() error = %v", err)
+	}
+	want := markdownTableStart +
+		"| `host` | Host \\| IP | `localhost` | `^[a-z\\|.]+$` | yes |\n" +
+		"| `password` | .Password | - | - | no |\n"
+	if got != want {
+		t.Errorf("MarkdownTable() = %q, want %q", got, want)
+	}
+}
+
+func TestPromptList_ManTable(t *testing.T) {
+	got, err := makeSchemaTestList().ManTable()
+	if err != nil {
+		t.Fatalf("ManTable() error = %v", err)
+	}
+	want := manTableStart +
+		"Key\tPrompt\tDefault\tPattern\tRequired\n" +
+		"host\tHost | IP\tlocalhost\t^[a-z|.]+$\tyes\n" +
+		"password\t\\&.Password\t-\t-\tno\n" +
+		manTableEnd
+	if got != want {
+		t.Errorf("ManTable() = %q, want %q", got, want)
+	}
+}
+
+func TestPromptList_DocumentationMissingKey(t *testing.T) {
+	l := MakePromptList(Prompt{PromptMessage: "No Key"})
+	if _, err := l.JSONSchema(); err != errMissingKey {
+		t.Errorf("JSONSchema() error = %v, want %v", err, errMissingKey)
+	}
+	if _, err := l.MarkdownTable(); err != errMissingKey {
+		t.Errorf("MarkdownTable() error = %v, want %v", err, errMissingKey)
+	}
+	if _, err := l.ManTable(); err != errMissingKey {
+		t.Errorf("ManTable() error = %v, want %v", err, errMissingKey)
+	}
+}
+
+func TestPromptList_DocumentationDuplicateKey(t *testing.T) {
+	l := MakePromptList(Prompt{MapKey: "host"}, Prompt{MapKey: "port"}, Prompt{MapKey: "host"})
+	if _, err := l.JSONSchema(); !errors.Is(err, errDuplicateKey) {
+		t.Errorf("JSONSchema() error = %v, want %v", err, errDuplicateKey)
+	}
+	if _, err := l.MarkdownTable(); !errors.Is(err, errDuplicateKey) {
+		t.Errorf("MarkdownTable() error = %v, want %v", err, errDuplicateKey)
+	}
+	if _, err := l.ManTable(); !errors.Is(err, errDuplicateKey) {
+		t.Errorf("ManTable() error = %v, want %v", err, errDuplicateKey)
+	}
+}
+
+func TestPromptList_JSONSchemaRE2OnlyPattern(t *testing.T) {
+	l := MakePromptList(
+		Prompt{MapKey: "name", InputValidatorRegex: regexp.MustCompile(`(?i)^[a-z]+$`)},
+		Prompt{MapKey: "id", InputValidatorRegex: regexp.MustCompile(`\A\d+\z`)},
+		Prompt{MapKey: "tag", InputValidatorRegex: regexp.MustCompile(`^(?P<tag>[a-z]+)$`)},
+	)
+	got, err := l.JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	if strings.Contains(string(got), `"pattern"`) {
+		t.Errorf("JSONSchema() includes RE2 only patterns: %s", got)
+	}
+}
+
+func Test_markdownCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		wantText string
+	}{
+		{name: "empty", s: "", wantText: "-"},
+		{name: "plain", s: "localhost", wantText: "`localhost`"},
+		{name: "pipe", s: "a|b", wantText: "`a\\|b`"},
+		{name: "backtick", s: "a`b", wantText: "``a`b``"},
+		{name: "backtick run", s: "a``b`c", wantText: "```a``b`c```"},
+		{name: "backtick at ends", s: "`a`", wantText: "`` `a` ``"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdownCode(tt.s); got != tt.wantText {
+				t.Errorf("markdownCode() = %q, want %q", got, tt.wantText)
+			}
+		})
+	}
+}
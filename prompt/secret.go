@@ -0,0 +1,137 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+const (
+	redactedSecret        = "[REDACTED]"
+	secretInitialCapacity = 64
+)
+
+var errNotPassword = errors.New("ShowSecret requires IsPassword to be set")
+
+// Secret holds a password returned by ShowSecret. Unlike a string it can be wiped once used, and it never reveals its
+// contents when printed or logged
+type Secret struct {
+	b []byte
+}
+
+// Bytes returns the secret. The returned slice is shared with the Secret, so it is zeroed by Wipe
+func (s *Secret) Bytes() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.b
+}
+
+// Wipe overwrites the secret with zeros
+func (s *Secret) Wipe() {
+	if s == nil {
+		return
+	}
+	wipe(s.b)
+	s.b = s.b[:0]
+}
+
+// String returns a placeholder instead of the secret
+func (s Secret) String() string {
+	return redactedSecret
+}
+
+// Format prints a placeholder instead of the secret for every verb, including %x and %#v
+func (s Secret) Format(f fmt.State, verb rune) {
+	io.WriteString(f, redactedSecret)
+}
+
+// MarshalJSON encodes a placeholder instead of the secret
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redactedSecret + `"`), nil
+}
+
+// ShowSecret Displays an IsPassword Prompt and returns the input as a Secret. On a terminal the input is never
+// converted to a string, unless InputValidatorFunc, InputErrorValidatorFunc or StrengthMeter is set as they work on
+// strings. OutputSerializerFunc is not applied. Blocks until valid input is received
+func (h *Prompt) ShowSecret() (*Secret, error) {
+	if !h.IsPassword {
+		return nil, errNotPassword
+	}
+	if err := h.checkRenderer(); err != nil {
+		return nil, err
+	}
+	h.recorder.recordPrompt(h)
+	ret, err := h.showSecret()
+	if err == nil {
+		h.recorder.recordAnswer(h, ret)
+	}
+	return ret, err
+}
+
+func (h *Prompt) showSecret() (*Secret, error) {
+	h.initializeScanner()
+	h.stopActivities()
+	h.beginRegion()
+	for {
+		h.showPrompt()
+		password, err := h.readConfirmedPassword()
+		if err != nil {
+			return nil, fmt.Errorf(inputErrorTemplate, err)
+		}
+		if len(password) == 0 {
+			if h.hasDefault() {
+				h.displaySummary(h.DefaultAsString)
+				return &Secret{b: []byte(h.DefaultAsString)}, nil
+			}
+			if h.AllowNil {
+				h.displaySummary("")
+				return nil, nil
+			}
+			h.displayInvalidInputMessage("")
+			continue
+		}
+		if err := h.validateSecret(password); err != nil {
+			wipe(password)
+			h.displayValidationError("", err)
+			continue
+		}
+		h.displaySummary(passwordSummaryMask)
+		return &Secret{b: password}, nil
+	}
+}
+
+// validateSecret validates password like validate, only converting it to a string for validator funcs
+func (h *Prompt) validateSecret(password []byte) error {
+	if h.InputValidatorRegex != nil && !h.InputValidatorRegex.Match(password) {
+		return errInvalidInput
+	}
+	if h.InputValidatorFunc == nil && h.InputErrorValidatorFunc == nil {
+		return nil
+	}
+	return h.validate(string(password))
+}
+
+// wipe overwrites b with zeros
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// appendSecretRune appends the UTF-8 encoding of r to b. If b has to grow, its old backing array is wiped so no copy
+// of the secret is left behind
+func appendSecretRune(b []byte, r rune) []byte {
+	var encoded [utf8.UTFMax]byte
+	n := utf8.EncodeRune(encoded[:], r)
+	if len(b)+n > cap(b) {
+		grown := make([]byte, len(b), 2*cap(b)+n)
+		copy(grown, b)
+		wipe(b)
+		b = grown
+	}
+	b = append(b, encoded[:n]...)
+	wipe(encoded[:])
+	return b
+}
@@ -0,0 +1,121 @@
+package prompt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSecret_Redacted(t *testing.T) {
+	s := &Secret{b: []byte("hunter2")}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d"} {
+		if got := fmt.Sprintf(format, s); got != "[REDACTED]" {
+			t.Errorf("Sprintf(%q) = %q, want redacted", format, got)
+		}
+	}
+	if got := fmt.Sprint(map[string]interface{}{"password": s}); strings.Contains(got, "hunter2") {
+		t.Errorf("Sprint() = %q, want redacted", got)
+	}
+	data, err := json.Marshal(map[string]interface{}{"password": s})
+	if err != nil || string(data) != `{"password":"[REDACTED]"}` {
+		t.Errorf("json.Marshal() = %s, %v, want redacted", data, err)
+	}
+
+	held := struct{ Password Secret }{*s}
+	for _, format := range []string{"%v", "%+v", "%#v"} {
+		if got := fmt.Sprintf(format, *s); got != "[REDACTED]" {
+			t.Errorf("Sprintf(%q) of a Secret value = %q, want redacted", format, got)
+		}
+		if got := fmt.Sprintf(format, held); strings.Contains(got, "104") || !strings.Contains(got, "[REDACTED]") {
+			t.Errorf("Sprintf(%q) of a struct holding a Secret = %q, want redacted", format, got)
+		}
+	}
+	data, err = json.Marshal(held)
+	if err != nil || string(data) != `{"Password":"[REDACTED]"}` {
+		t.Errorf("json.Marshal() of a struct holding a Secret = %s, %v, want redacted", data, err)
+	}
+}
+
+func TestSecret_Wipe(t *testing.T) {
+	s := &Secret{b: []byte("hunter2")}
+	b := s.Bytes()
+	if string(b) != "hunter2" {
+		t.Fatalf("Bytes() = %q, want %q", b, "hunter2")
+	}
+	s.Wipe()
+	if !bytes.Equal(b, make([]byte, len(b))) {
+		t.Errorf("Wipe() left %q, want zeros", b)
+	}
+	if len(s.Bytes()) != 0 {
+		t.Errorf("Bytes() after Wipe() = %q, want empty", s.Bytes())
+	}
+
+	var nilSecret *Secret
+	nilSecret.Wipe()
+	if nilSecret.Bytes() != nil {
+		t.Errorf("Bytes() of nil Secret = %q, want nil", nilSecret.Bytes())
+	}
+}
+
+func TestAppendSecretRune(t *testing.T) {
+	b := make([]byte, 0, 2)
+	old := b[:cap(b)]
+	b = appendSecretRune(b, 'a')
+	b = appendSecretRune(b, 'ü')
+	if string(b) != "aü" {
+		t.Fatalf("appendSecretRune() = %q, want %q", b, "aü")
+	}
+	if !bytes.Equal(old, make([]byte, len(old))) {
+		t.Errorf("appendSecretRune() left %q in the old backing array, want zeros", old)
+	}
+}
+
+func TestPrompt_ShowSecret(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   Prompt
+		options  []Opt
+		input    string
+		want     string
+		wantNil  bool
+		wantErr  bool
+		wantText string
+	}{
+		{name: "Masked", prompt: Prompt{PasswordMask: '*'}, options: []Opt{WithLineEditor()}, input: "pässword\r", want: "pässword"},
+		{name: "Reader", input: "hunter2\n", want: "hunter2"},
+		{name: "Confirmed", prompt: Prompt{ConfirmPassword: true}, input: "a\nb\nzz\nzz\n", want: "zz", wantText: "Entries do not match"},
+		{name: "Regex", prompt: Prompt{InputValidatorRegex: regexp.MustCompile(`^.{4,}$`)}, input: "abc\nabcd\n", want: "abcd", wantText: "\nInvalid Input\n\n"},
+		{name: "Validator", prompt: Prompt{PasswordMask: '*', InputValidatorFunc: func(s string) bool { return s != "bad" }}, options: []Opt{WithLineEditor()}, input: "bad\rgood\r", want: "good"},
+		{name: "Default", prompt: Prompt{PasswordMask: '*', DefaultAsString: "changeme"}, options: []Opt{WithLineEditor()}, input: "\r", want: "changeme"},
+		{name: "AllowNil", prompt: Prompt{PasswordMask: '*', AllowNil: true}, options: []Opt{WithLineEditor()}, input: "\r", wantNil: true},
+		{name: "Empty re-prompts", prompt: Prompt{PasswordMask: '*'}, options: []Opt{WithLineEditor()}, input: "\rx\r", want: "x"},
+		{name: "EOF", prompt: Prompt{PasswordMask: '*'}, options: []Opt{WithLineEditor()}, input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			p := tt.prompt
+			p.PromptMessage = "Password"
+			p.IsPassword = true
+			p.SetOptions(append(tt.options, WithReader(strings.NewReader(tt.input)), WithWriter(out))...)
+			got, err := p.ShowSecret()
+			if (err != nil) != tt.wantErr || (got == nil) != (tt.wantNil || tt.wantErr) || (got != nil && string(got.Bytes()) != tt.want) {
+				t.Fatalf("ShowSecret() = %q, %v, want %q", got.Bytes(), err, tt.want)
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("ShowSecret() output = %q, want %q", out.String(), tt.wantText)
+			}
+			if tt.want != "" && p.DefaultAsString == "" && strings.Contains(out.String(), tt.want) {
+				t.Errorf("ShowSecret() output = %q, secret was echoed", out.String())
+			}
+		})
+	}
+
+	p := &Prompt{PromptMessage: "Name"}
+	if _, err := p.ShowSecret(); err != errNotPassword {
+		t.Errorf("ShowSecret() error = %v, want %v", err, errNotPassword)
+	}
+}
@@ -0,0 +1,465 @@
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultSelectPageSize  = 10
+	ansiClearScreenDown    = "\x1b[J"
+	ansiCursorUpTpl        = "\x1b[%dA"
+	ansiReset              = "\x1b[0m"
+	selectCursor           = "> "
+	selectNoCursor         = "  "
+	selectIndent           = "  "
+	selectNumberedTemplate = "  %v) %v\n"
+)
+
+// Option is a choice offered by a Select
+type Option struct {
+	Label string      // Text displayed and matched against the typed query
+	Value interface{} // Returned by Show when the option is chosen; If nil, Label is returned, passed through OutputSerializerFunc if set
+}
+
+// OptionsSource loads the options of a Select for the typed query. It is called when the Select is shown and again
+// whenever the query changes, so options can be fetched lazily, ie: from a remote API which searches server side
+type OptionsSource func(query string) ([]Option, error)
+
+// MakeOptions is a helper function used to assemble Options from labels
+func MakeOptions(labels ...string) []Option {
+	var ret []Option
+	for _, l := range labels {
+		ret = append(ret, Option{Label: l})
+	}
+	return ret
+}
+
+// Select asks the user to choose one of a list of options. On a terminal, typing filters the options with fuzzy
+// matching, highlighting the matched characters; Up/Down move the selection, PageUp/PageDown scroll a page and Enter
+// chooses. Otherwise the options are listed with numbers and the user enters a number or a query to filter by.
+// PromptMessage, MapKey, AllowNil, InvalidInputMessage and options set with SetOptions apply as for a Prompt;
+// DefaultAsString preselects the option with that label
+type Select struct {
+	Prompt
+
+	Options             []Option      // The options to choose from
+	OptionsSource       OptionsSource // If set, loads the options instead of Options
+	PageSize            int           // The number of options visible at once. This will default to 10
+	SuppressFuzzyFilter bool          // By default, options are filtered and ranked by fuzzy matching against the query. Setting SuppressFuzzyFilter shows options as loaded, ie: when OptionsSource already searches
+}
+
+// Show Displays the Select and returns the Value of the chosen option. Blocks until an option is chosen
+func (s *Select) Show() (interface{}, error) {
+	if err := s.checkRenderer(); err != nil {
+		return nil, err
+	}
+	s.recorder.recordPrompt(&s.Prompt)
+	ret, err := s.show()
+	if err == nil {
+		s.recorder.recordAnswer(&s.Prompt, ret)
+	}
+	return ret, err
+}
+
+// ShowWithContext - Same as Show but is context aware so can be canceled / timed out
+func (s *Select) ShowWithContext(ctx context.Context) (interface{}, error) {
+	resultChan, errChan := s.showAsync()
+	select {
+	case ret := <-resultChan:
+		return ret, nil
+	case err := <-errChan:
+		return nil, err
+	case <-ctx.Done():
+		// The abandoned read may still be blocked with the terminal in raw mode
+		restoreRawTerminals()
+		return nil, errors.New("call was canceled by context")
+	}
+}
+
+func (s *Select) showAsync() (<-chan interface{}, <-chan error) {
+	// Buffered so the goroutine can finish after ShowWithContext has stopped listening
+	resultChan := make(chan interface{}, 1)
+	errorChan := make(chan error, 1)
+	go func() {
+		ret, err := s.Show()
+		if err != nil {
+			errorChan <- err
+			return
+		}
+		resultChan <- ret
+	}()
+	return resultChan, errorChan
+}
+
+func (s *Select) show() (interface{}, error) {
+	s.stopActivities()
+	s.beginRegion()
+	var (
+		o   Option
+		ok  bool
+		err error
+	)
+	if s.shouldUseLineEditor() {
+		o, ok, err = s.showInteractive()
+	} else {
+		o, ok, err = s.showNumbered()
+	}
+	if err != nil {
+		return nil, fmt.Errorf(inputErrorTemplate, err)
+	}
+	if !ok {
+		s.displaySummary("")
+		return nil, nil
+	}
+	s.displaySummary(o.Label)
+	if o.Value != nil {
+		return o.Value, nil
+	}
+	return s.serializeIfRequired(o.Label)
+}
+
+// selectView is the state of a Select being shown
+type selectView struct {
+	s        *Select
+	query    []rune
+	matches  []optionMatch
+	err      error // Error of the last OptionsSource call
+	selected int
+	offset   int // Index of the first visible match
+	row      int // Row of the cursor below the first row of the prompt, while the prompt line is wrapped
+
+	mu      sync.Mutex // Held except while waiting for a key; Log output is written above the Select by a LogWriter holding it
+	reading bool       // Set while showInteractive waits for keys, so the Select may be redrawn
+}
+
+func (s *Select) newView() *selectView {
+	v := &selectView{s: s}
+	v.load()
+	for i, m := range v.matches {
+		if s.hasDefault() && m.option.Label == s.DefaultAsString {
+			v.moveTo(i)
+			break
+		}
+	}
+	return v
+}
+
+// load refreshes the matches for the current query
+func (v *selectView) load() {
+	options := v.s.Options
+	v.err = nil
+	if v.s.OptionsSource != nil {
+		options, v.err = v.s.OptionsSource(string(v.query))
+	}
+	v.matches = filterOptions(options, string(v.query), !v.s.SuppressFuzzyFilter)
+	v.selected, v.offset = 0, 0
+}
+
+// moveTo selects the match at index i and scrolls it into view
+func (v *selectView) moveTo(i int) {
+	if i >= len(v.matches) {
+		i = len(v.matches) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	v.selected = i
+	if v.selected < v.offset {
+		v.offset = v.selected
+	}
+	if v.selected >= v.offset+v.s.getPageSize() {
+		v.offset = v.selected - v.s.getPageSize() + 1
+	}
+}
+
+func (v *selectView) visible() []optionMatch {
+	end := v.offset + v.s.getPageSize()
+	if end > len(v.matches) {
+		end = len(v.matches)
+	}
+	return v.matches[v.offset:end]
+}
+
+func (s *Select) showInteractive() (Option, bool, error) {
+	if f, ok := s.getInputTerminal(); ok {
+		restore, err := makeRaw(f)
+		if err != nil {
+			return Option{}, false, err
+		}
+		defer restore()
+	}
+	in := s.getInput()
+	defer s.recordInput()
+	promptText := s.getPromptText()
+	promptText = promptText[strings.LastIndex(promptText, "\n")+1:]
+	out := s.getOutputWriter()
+
+	v := s.newView()
+	v.mu.Lock()
+	v.reading = true
+	defer func() {
+		v.reading = false
+		v.mu.Unlock()
+	}()
+	defer addLiveLine(&liveLine{
+		w:    s.getRawOutputWriter(),
+		lock: &v.mu,
+		clear: func() {
+			if v.reading {
+				fmt.Fprintf(out, "%v\r%v", v.upToPrompt(), ansiClearScreenDown)
+				v.row = 0
+			}
+		},
+		redraw: func() {
+			if v.reading {
+				v.render(out, promptText)
+			}
+		},
+	})()
+	for {
+		v.render(out, promptText)
+		v.mu.Unlock()
+		k, err := readKey(in)
+		s.recordInput()
+		v.mu.Lock()
+		if err != nil {
+			return Option{}, false, err
+		}
+		switch k.code {
+		case keyEnter:
+			if len(v.matches) > 0 {
+				chosen := v.matches[v.selected].option
+				fmt.Fprintf(out, "%v\r%v%v%v\r\n", v.upToPrompt(), ansiClearScreenDown, promptText, chosen.Label)
+				return chosen, true, nil
+			}
+			if s.AllowNil && len(v.query) == 0 {
+				fmt.Fprintf(out, "%v\r%v%v\r\n", v.upToPrompt(), ansiClearScreenDown, promptText)
+				return Option{}, false, nil
+			}
+			fmt.Fprint(out, bell)
+		case keyInterrupt:
+			fmt.Fprintf(out, "%v\r%v%v%v^C\r\n", v.upToPrompt(), ansiClearScreenDown, promptText, string(v.query))
+			return Option{}, false, ErrInterrupted
+		case keyEOF:
+			if len(v.query) == 0 {
+				fmt.Fprintf(out, "%v\r%v%v\r\n", v.upToPrompt(), ansiClearScreenDown, promptText)
+				return Option{}, false, io.EOF
+			}
+		case keyRune:
+			v.query = append(v.query, k.r)
+			v.load()
+		case keyBackspace:
+			if len(v.query) > 0 {
+				v.query = v.query[:len(v.query)-1]
+				v.load()
+			}
+		case keyKillToStart:
+			v.query = v.query[:0]
+			v.load()
+		case keyUp:
+			v.moveTo(v.selected - 1)
+		case keyDown:
+			v.moveTo(v.selected + 1)
+		case keyPageUp:
+			v.moveTo(v.selected - s.getPageSize())
+		case keyPageDown:
+			v.moveTo(v.selected + s.getPageSize())
+		case keyHome:
+			v.moveTo(0)
+		case keyEnd:
+			v.moveTo(len(v.matches) - 1)
+		}
+	}
+}
+
+// render draws the prompt line with the query, and the visible matches below it. If the width of the terminal is
+// known, lines longer than it are wrapped and the cursor is returned across the rows they take
+func (v *selectView) render(out io.Writer, promptText string) {
+	cols := v.s.getTerminalWidth()
+	if cols > 0 {
+		v.renderWrapped(out, promptText, cols)
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\r%v%v%v", ansiClearScreenDown, promptText, string(v.query))
+	lines := v.lines()
+	for _, l := range lines {
+		b.WriteString("\r\n" + l)
+	}
+	// Return to the end of the query
+	fmt.Fprintf(&b, ansiCursorUpTpl+"\r%v%v", len(lines), promptText, string(v.query))
+	fmt.Fprint(out, b.String())
+}
+
+func (v *selectView) renderWrapped(out io.Writer, promptText string, cols int) {
+	var b strings.Builder
+	text := promptText + string(v.query)
+	fmt.Fprintf(&b, "%v\r%v%v", v.upToPrompt(), ansiClearScreenDown, text)
+	row, col := cursorPosition(text, cols)
+	if row > 0 && col == 0 {
+		// The text ends at the last column; Terminals only wrap on the next character, so wrap explicitly
+		b.WriteString("\r\n")
+	}
+	rows := 0
+	for _, l := range v.lines() {
+		wrapped := wrapText(l, cols)
+		b.WriteString("\r\n" + strings.ReplaceAll(wrapped, "\n", "\r\n"))
+		rows += strings.Count(wrapped, "\n") + 1
+	}
+	// Return to the end of the query
+	b.WriteString(moveCursor(row+rows, row, col))
+	v.row = row
+	fmt.Fprint(out, b.String())
+}
+
+// upToPrompt returns the escape sequence moving the cursor up to the first row of the prompt
+func (v *selectView) upToPrompt() string {
+	if v.row > 0 {
+		return fmt.Sprintf(ansiCursorUpTpl, v.row)
+	}
+	return ""
+}
+
+// lines returns the rows displayed below the prompt line: the visible matches and the page footer, or a message if
+// there are none
+func (v *selectView) lines() []string {
+	var lines []string
+	switch {
+	case v.err != nil:
+		lines = append(lines, v.s.message(i18n.SelectSourceError, v.err))
+	case len(v.matches) == 0:
+		lines = append(lines, v.s.message(i18n.SelectNoMatches))
+	default:
+		for i, m := range v.visible() {
+			if v.offset+i == v.selected {
+				lines = append(lines, v.s.renderSelected(m))
+				continue
+			}
+			lines = append(lines, selectNoCursor+highlight(m.option.Label, m.positions, v.s.getMatchSequence(), ansiReset))
+		}
+		if len(v.matches) > v.s.getPageSize() {
+			lines = append(lines, selectIndent+v.s.message(i18n.SelectFooter, v.offset+1, v.offset+len(v.visible()), len(v.matches)))
+		}
+	}
+	return lines
+}
+
+// renderSelected returns the row of the selected match in the Selected style of the Theme
+func (s *Select) renderSelected(m optionMatch) string {
+	seq := ""
+	if s.theme != nil {
+		seq = s.theme.Selected.sequence(s.getColorLevel())
+	}
+	match := s.getMatchSequence()
+	if seq == "" {
+		return selectCursor + highlight(m.option.Label, m.positions, match, ansiReset)
+	}
+	return seq + selectCursor + highlight(m.option.Label, m.positions, match, ansiReset+seq) + ansiReset
+}
+
+// getMatchSequence returns the escape sequence highlighting the runes matched by the filter: The Match style of the
+// Theme, or bold underline if no Theme is set. Empty if the output displays no colors
+func (s *Select) getMatchSequence() string {
+	style := defaultMatchStyle
+	if s.theme != nil {
+		style = s.theme.Match
+	}
+	return style.sequence(s.getColorLevel())
+}
+
+// highlight wraps the runes of label at positions in start, followed by after to restore the style of the row; label
+// is returned unchanged if start is empty
+func highlight(label string, positions []int, start string, after string) string {
+	if len(positions) == 0 || start == "" {
+		return label
+	}
+	var b strings.Builder
+	next := 0
+	for i, r := range []rune(label) {
+		if next < len(positions) && positions[next] == i {
+			b.WriteString(start + string(r) + after)
+			next++
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// showNumbered lists the options with numbers and reads lines, for input which isn't a terminal. A number chooses
+// the listed option; Any other input filters the options, choosing the only or exactly matching one
+func (s *Select) showNumbered() (Option, bool, error) {
+	s.initializeScanner()
+	out := s.getOutputWriter()
+	v := s.newView()
+	for {
+		v.printNumbered(out)
+		s.showPrompt()
+		input, err := s.readRegularInput()
+		if err != nil {
+			return Option{}, false, err
+		}
+		if s.isHelpRequest(input) {
+			s.displayHelp()
+			continue
+		}
+		if input == "" {
+			if len(v.query) == 0 && s.hasDefault() && len(v.matches) > 0 && v.matches[v.selected].option.Label == s.DefaultAsString {
+				return v.matches[v.selected].option, true, nil
+			}
+			if s.AllowNil && !s.hasDefault() {
+				return Option{}, false, nil
+			}
+			s.displayInvalidInputMessage("")
+			continue
+		}
+		if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(v.visible()) {
+			return v.visible()[n-1].option, true, nil
+		}
+		v.query = []rune(input)
+		v.load()
+		for _, m := range v.matches {
+			if strings.EqualFold(m.option.Label, input) {
+				return m.option, true, nil
+			}
+		}
+		if len(v.matches) == 1 && v.err == nil {
+			return v.matches[0].option, true, nil
+		}
+		if len(v.matches) == 0 {
+			s.displayInvalidInputMessage(input)
+			v.query = nil
+			v.load()
+		}
+	}
+}
+
+func (v *selectView) printNumbered(out io.Writer) {
+	if v.err != nil {
+		fmt.Fprint(out, v.s.renderErrorMessage(v.s.message(i18n.SelectSourceError, v.err)))
+		return
+	}
+	for i, m := range v.visible() {
+		fmt.Fprintf(out, selectNumberedTemplate, i+1, m.option.Label)
+	}
+	if more := len(v.matches) - len(v.visible()); more > 0 {
+		fmt.Fprintln(out, selectIndent+v.s.message(i18n.SelectMore, more))
+	}
+	if v.s.isAccessible() && len(v.visible()) > 0 {
+		fmt.Fprintln(out, v.s.message(i18n.AccessibleSelectHint, len(v.visible())))
+	}
+}
+
+func (s *Select) getPageSize() int {
+	if s.PageSize > 0 {
+		return s.PageSize
+	}
+	return defaultSelectPageSize
+}
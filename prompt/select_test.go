@@ -0,0 +1,165 @@
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func makeRegionOptions(n int) []Option {
+	var ret []Option
+	for i := 1; i <= n; i++ {
+		ret = append(ret, Option{Label: fmt.Sprintf("region-%03d", i), Value: i})
+	}
+	return ret
+}
+
+func TestSelect_Interactive(t *testing.T) {
+	tests := []struct {
+		name    string
+		sel     Select
+		input   string
+		want    interface{}
+		wantErr error
+	}{
+		{name: "Enter chooses first", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "\r", want: "alpha"},
+		{name: "Down", sel: Select{Options: MakeOptions("alpha", "beta", "gamma")}, input: "\x1b[B\x1b[B\r", want: "gamma"},
+		{name: "Down stops at last", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "\x1b[B\x1b[B\x1b[B\r", want: "beta"},
+		{name: "Up stops at first", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "\x1b[A\r", want: "alpha"},
+		{name: "Filter", sel: Select{Options: MakeOptions("alpha", "beta", "gamma")}, input: "gm\r", want: "gamma"},
+		{name: "Backspace widens filter", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "bx\x7f\r", want: "beta"},
+		{name: "Ctrl-U clears filter", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "be\x15\r", want: "alpha"},
+		{name: "No match rings bell", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "x\r\x7f\r", want: "alpha"},
+		{name: "Value", sel: Select{Options: makeRegionOptions(3)}, input: "\x1b[B\r", want: 2},
+		{name: "Page down", sel: Select{Options: makeRegionOptions(30), PageSize: 5}, input: "\x1b[6~\x1b[6~\r", want: 11},
+		{name: "Page up", sel: Select{Options: makeRegionOptions(30), PageSize: 5}, input: "\x1b[6~\x1b[6~\x1b[5~\x1b[A\r", want: 5},
+		{name: "End", sel: Select{Options: makeRegionOptions(30)}, input: "\x05\r", want: 30},
+		{name: "Default preselected", sel: Select{Prompt: Prompt{DefaultAsString: "beta"}, Options: MakeOptions("alpha", "beta")}, input: "\r", want: "beta"},
+		{name: "Serializer", sel: Select{Prompt: Prompt{OutputSerializerFunc: func(s string) (interface{}, error) { return strings.ToUpper(s), nil }}, Options: MakeOptions("alpha")}, input: "\r", want: "ALPHA"},
+		{name: "AllowNil", sel: Select{Prompt: Prompt{AllowNil: true}}, input: "\r", want: nil},
+		{name: "Interrupt", sel: Select{Options: MakeOptions("alpha")}, input: "\x03", wantErr: ErrInterrupted},
+		{name: "EOF", sel: Select{Options: MakeOptions("alpha")}, input: "", wantErr: io.EOF},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.sel.PromptMessage = "Region"
+			tt.sel.SetOptions(WithLineEditor(), WithReader(strings.NewReader(tt.input)), WithWriter(new(bytes.Buffer)))
+			got, err := tt.sel.Show()
+			if !errors.Is(err, tt.wantErr) || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Show() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelect_InteractiveRender(t *testing.T) {
+	out := new(bytes.Buffer)
+	s := &Select{Prompt: Prompt{PromptMessage: "Region"}, Options: makeRegionOptions(30), PageSize: 3}
+	s.SetOptions(WithLineEditor(), WithColorLevel(ColorLevel16), WithReader(strings.NewReader("r1\x1b[B\x1b[B\x1b[B\r")), WithWriter(out))
+	if _, err := s.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	for _, want := range []string{
+		"> region-001\r\n  region-002\r\n  region-003",
+		"(1-3 of 30)",
+		"> \x1b[1;4mr\x1b[0megion-0\x1b[1;4m1\x1b[0m0",
+		"(2-4 of 12)",
+		"\x1b[4A\rRegion: r1",
+		"\r\x1b[JRegion: region-013\r\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Show() output = %q, want %q", out.String(), want)
+		}
+	}
+}
+
+func TestSelect_InteractiveRenderWrapped(t *testing.T) {
+	out := new(bytes.Buffer)
+	s := &Select{Prompt: Prompt{PromptMessage: "Region"}, Options: MakeOptions("us-east-1 North Virginia", "eu-west-1")}
+	s.SetOptions(WithLineEditor(), WithReader(strings.NewReader("\r")), WithWriter(out), WithTerminalWidth(16))
+	if _, err := s.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	want := "\r\x1b[JRegion: " +
+		"\r\n> us-east-1\r\nNorth Virginia" + // The option is wrapped at a word boundary
+		"\r\n  eu-west-1" +
+		"\x1b[3A\r\x1b[8C" + // Back up across every row
+		"\r\x1b[JRegion: us-east-1 North Virginia\r\n"
+	if out.String() != want {
+		t.Errorf("Show() output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestSelect_OptionsSource(t *testing.T) {
+	var queries []string
+	source := func(query string) ([]Option, error) {
+		queries = append(queries, query)
+		if query == "err" {
+			return nil, errors.New("service unavailable")
+		}
+		return MakeOptions(query+"-1", query+"-2"), nil
+	}
+	out := new(bytes.Buffer)
+	s := &Select{Prompt: Prompt{PromptMessage: "User"}, OptionsSource: source}
+	s.SetOptions(WithLineEditor(), WithReader(strings.NewReader("err\x7f\x7f\x7fbob\x1b[B\r")), WithWriter(out))
+	got, err := s.Show()
+	if err != nil || got != "bob-2" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "bob-2")
+	}
+	if want := []string{"", "e", "er", "err", "er", "e", "", "b", "bo", "bob"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("OptionsSource called with %q, want %q", queries, want)
+	}
+	if !strings.Contains(out.String(), "Cannot load options: service unavailable") {
+		t.Errorf("Show() output = %q, want source error", out.String())
+	}
+
+	s = &Select{Prompt: Prompt{PromptMessage: "User"}, OptionsSource: source, SuppressFuzzyFilter: true}
+	s.SetOptions(WithLineEditor(), WithReader(strings.NewReader("x\r")), WithWriter(new(bytes.Buffer)))
+	if got, err := s.Show(); err != nil || got != "x-1" {
+		t.Errorf("Show() = %v, %v, want %v", got, err, "x-1")
+	}
+}
+
+func TestSelect_Numbered(t *testing.T) {
+	tests := []struct {
+		name     string
+		sel      Select
+		input    string
+		want     interface{}
+		wantText string
+	}{
+		{name: "Number", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "2\n", want: "beta", wantText: "  1) alpha\n  2) beta\nRegion: "},
+		{name: "Only match", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "bt\n", want: "beta"},
+		{name: "Exact label", sel: Select{Options: MakeOptions("beta", "beta-2")}, input: "BETA\n", want: "beta"},
+		{name: "Long list is paged", sel: Select{Options: makeRegionOptions(30)}, input: "2\n", want: 2, wantText: "... 20 more, type to filter"},
+		{name: "Filter narrows list", sel: Select{Options: makeRegionOptions(30)}, input: "-02\n2\n", want: 21, wantText: "  1) region-020\n  2) region-021\n"},
+		{name: "No match", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "x\n1\n", want: "alpha", wantText: "Invalid Input [x]"},
+		{name: "Number out of range is a query", sel: Select{Options: MakeOptions("alpha", "beta")}, input: "3\nbeta\n", want: "beta", wantText: "Invalid Input [3]"},
+		{name: "Default", sel: Select{Prompt: Prompt{DefaultAsString: "beta"}, Options: MakeOptions("alpha", "beta")}, input: "\n", want: "beta", wantText: "Region [beta]: "},
+		{name: "AllowNil", sel: Select{Prompt: Prompt{AllowNil: true}, Options: MakeOptions("alpha")}, input: "\n", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			tt.sel.PromptMessage = "Region"
+			tt.sel.SetOptions(WithReader(strings.NewReader(tt.input)), WithWriter(out))
+			got, err := tt.sel.Show()
+			if err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Show() = %v, %v, want %v", got, err, tt.want)
+			}
+			if !strings.Contains(out.String(), tt.wantText) {
+				t.Errorf("Show() output = %q, want %q", out.String(), tt.wantText)
+			}
+		})
+	}
+
+	s := &Select{Prompt: Prompt{PromptMessage: "Region"}, Options: MakeOptions("alpha")}
+	s.SetOptions(WithReader(strings.NewReader("")), WithWriter(new(bytes.Buffer)))
+	if _, err := s.Show(); !errors.Is(err, io.EOF) {
+		t.Errorf("Show() error = %v, want %v", err, io.EOF)
+	}
+}
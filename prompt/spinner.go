@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	spinnerTemplate        = "%v %v"
+	spinnerLogTemplate     = "%v..."
+	spinnerElapsedTemplate = "%v... (%v)"
+)
+
+// DefaultSpinnerFrames are the frames of a Spinner if none are set
+var DefaultSpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Spinner shows that a slow operation is running, ie: between two prompts. On a terminal it animates on the current
+// line; Otherwise, or in accessible mode, Message is logged when started and every LogInterval. A Spinner is stopped
+// and erased when a Prompt or Select is displayed on the same Writer
+type Spinner struct {
+	Message     string        // Displayed after the animation; Change it with SetMessage while running
+	Frames      []string      // The frames of the animation. This will default to DefaultSpinnerFrames
+	Interval    time.Duration // The time each frame is displayed. This will default to 100ms
+	LogInterval time.Duration // The time between log lines if the Writer isn't a terminal. This will default to 10s
+	Writer      io.Writer     // Defaults to os.Stdout, as for a Prompt
+
+	mu       sync.Mutex
+	frame    int
+	activity *activity
+}
+
+// Start displays the spinner until Stop is called, with the Writer and intervals set at the time; Does nothing if it
+// is already running
+func (s *Spinner) Start() {
+	startActivity(&s.mu, &s.activity, func() *activity {
+		return &activity{
+			w:           getActivityWriter(s.Writer),
+			render:      s.render,
+			logLine:     s.logLine,
+			interval:    getActivityInterval(s.Interval, defaultActivityInterval),
+			logInterval: getActivityInterval(s.LogInterval, defaultActivityLogInterval),
+		}
+	})
+}
+
+// SetMessage changes the message displayed after the animation
+func (s *Spinner) SetMessage(message string) {
+	s.mu.Lock()
+	s.Message = message
+	a := s.activity
+	s.mu.Unlock()
+	if a != nil {
+		a.refresh()
+	}
+}
+
+// Stop stops and erases the spinner
+func (s *Spinner) Stop() {
+	s.StopWithMessage("")
+}
+
+// StopWithMessage stops and erases the spinner, and writes message on its own line, ie: "✔ Deployed"
+func (s *Spinner) StopWithMessage(message string) {
+	s.mu.Lock()
+	a := s.activity
+	s.mu.Unlock()
+	if a != nil {
+		a.halt(message)
+	}
+}
+
+// render returns the next frame followed by the message
+func (s *Spinner) render() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	frames := s.Frames
+	if len(frames) == 0 {
+		frames = DefaultSpinnerFrames
+	}
+	frame := frames[s.frame%len(frames)]
+	s.frame++
+	return fmt.Sprintf(spinnerTemplate, frame, s.Message)
+}
+
+// logLine returns the message, followed by the elapsed time once it has been running for a while
+func (s *Spinner) logLine(elapsed time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elapsed < time.Second {
+		return fmt.Sprintf(spinnerLogTemplate, s.Message)
+	}
+	return fmt.Sprintf(spinnerElapsedTemplate, s.Message, elapsed.Round(time.Second))
+}
+
+func getActivityWriter(w io.Writer) io.Writer {
+	if w != nil {
+		return w
+	}
+	return defaultOutputWriter
+}
+
+func getActivityInterval(d time.Duration, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
@@ -0,0 +1,83 @@
+package prompt
+
+import (
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"github.com/bchivari/go-cli-prompt/validation"
+	"io"
+	"strings"
+)
+
+const (
+	ansiSaveCursor        = "\x1b7"
+	ansiRestoreCursor     = "\x1b8"
+	ansiCursorUp          = "\x1b[A"
+	ansiCursorDown        = "\x1b[B"
+	strengthMeterTemplate = "%v: [%v%v] %v"
+	strengthLineTemplate  = "%v: %v\n"
+	strengthMeterFilled   = "#"
+	strengthMeterEmpty    = "-"
+	strengthMeterSize     = int(validation.StrengthVeryStrong) + 1
+)
+
+// strengthMeter draws the strength of the password being typed on the line below the input. A nil strengthMeter
+// draws nothing
+type strengthMeter struct {
+	out       io.Writer
+	rate      func(string) validation.Strength
+	localizer i18n.Localizer
+}
+
+// displayStrength describes the strength of password on its own line once it is entered, if the strength meter
+// isn't drawn below the input while typing: Without PasswordMask, without the line editor or in accessible mode
+func (h *Prompt) displayStrength(password []byte) {
+	if h.StrengthMeter == nil || h.readsMaskedPassword() || len(password) == 0 {
+		return
+	}
+	s := h.StrengthMeter(string(password))
+	fmt.Fprintf(h.getOutputWriter(), strengthLineTemplate, h.message(i18n.StrengthLabel), h.message(s.MessageID()))
+}
+
+func (h *Prompt) newStrengthMeter() *strengthMeter {
+	if h.StrengthMeter == nil {
+		return nil
+	}
+	return &strengthMeter{out: h.getOutputWriter(), rate: h.StrengthMeter, localizer: h.getLocalizer()}
+}
+
+// reserve moves to a new line and back, so drawing below the input never scrolls the terminal
+func (m *strengthMeter) reserve() {
+	if m == nil {
+		return
+	}
+	reserveLineBelow(m.out)
+}
+
+// update draws the strength of password; An empty password clears the meter
+func (m *strengthMeter) update(password []byte) {
+	if m == nil {
+		return
+	}
+	meter := ""
+	if len(password) > 0 {
+		s := m.rate(string(password))
+		filled := int(s) + 1
+		meter = fmt.Sprintf(strengthMeterTemplate, m.localizer.Message(i18n.StrengthLabel), strings.Repeat(strengthMeterFilled, filled),
+			strings.Repeat(strengthMeterEmpty, strengthMeterSize-filled), m.localizer.Message(s.MessageID()))
+	}
+	drawBelow(m.out, meter)
+}
+
+func (m *strengthMeter) clear() {
+	m.update(nil)
+}
+
+// reserveLineBelow moves to a new line and back, so drawing below the cursor never scrolls the terminal
+func reserveLineBelow(out io.Writer) {
+	fmt.Fprint(out, "\n"+ansiCursorUp)
+}
+
+// drawBelow replaces the line below the cursor with text, leaving the cursor in place; Empty text clears the line
+func drawBelow(out io.Writer, text string) {
+	fmt.Fprint(out, ansiSaveCursor+ansiCursorDown+"\r"+text+ansiClearToEnd+ansiRestoreCursor)
+}
@@ -0,0 +1,138 @@
+package prompt
+
+import (
+	"fmt"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	summaryMark     = "✔"
+	summaryTemplate = "%v %v%v%v\n"
+	ansiEscape      = 0x1b
+)
+
+// screenRegion counts the lines printed since a prompt was first displayed, so they can be cleared once it is
+// answered
+type screenRegion struct {
+	lines int
+	saved int // lines when the cursor position was saved, ie: "\x1b7"
+}
+
+// count adds the line feeds in b and the lines moved down by cursor down sequences, ie: "\x1b[2B", and subtracts the
+// lines moved up by cursor up sequences, ie: "\x1b[2A". Restoring a saved cursor position restores the count
+func (r *screenRegion) count(b []byte) {
+	for i := 0; i < len(b); i++ {
+		if b[i] == '\n' {
+			r.lines++
+			continue
+		}
+		if b[i] != ansiEscape || i+1 >= len(b) {
+			continue
+		}
+		switch b[i+1] {
+		case ansiSaveCursor[1]:
+			r.saved = r.lines
+			i++
+			continue
+		case ansiRestoreCursor[1]:
+			r.lines = r.saved
+			i++
+			continue
+		case '[':
+		default:
+			continue
+		}
+		n, j := 0, i+2
+		for ; j < len(b) && b[j] >= '0' && b[j] <= '9'; j++ {
+			n = n*10 + int(b[j]-'0')
+		}
+		if j < len(b) && (b[j] == 'A' || b[j] == 'B') {
+			if n == 0 {
+				n = 1
+			}
+			if b[j] == 'A' {
+				n = -n
+			}
+			r.lines += n
+			i = j
+		}
+	}
+	if r.lines < 0 {
+		r.lines = 0
+	}
+}
+
+// regionWriter counts the lines written to w in the screenRegion of p, if one is active
+type regionWriter struct {
+	w io.Writer
+	p *Prompt
+}
+
+func (rw *regionWriter) Write(b []byte) (int, error) {
+	if rw.p.region != nil {
+		rw.p.region.count(b)
+	}
+	return rw.w.Write(b)
+}
+
+// beginRegion starts counting the lines of the prompt if it is replaced by a summary once answered
+func (h *Prompt) beginRegion() {
+	h.region = nil
+	if h.answeredSummary && !h.isAccessible() && isTerminalWriter(h.getRawOutputWriter()) {
+		h.region = &screenRegion{}
+	}
+}
+
+// countEchoedLine counts the rows of a line of input echoed by the terminal after promptText, which aren't written by
+// the prompt. Input wider than the terminal wraps onto further rows
+func (h *Prompt) countEchoedLine(promptText string, line string) {
+	if _, ok := h.getInputTerminal(); ok && h.region != nil {
+		h.region.lines += echoedRows(promptText[strings.LastIndex(promptText, "\n")+1:]+line, h.getTerminalWidth())
+	}
+}
+
+// echoedRows returns the rows moved down by echoing text followed by a line feed, from the first column of a terminal
+// cols wide; 1 if the width is unknown
+func echoedRows(text string, cols int) int {
+	if cols <= 0 {
+		return 1
+	}
+	row, col := cursorPosition(text, cols)
+	if row > 0 && col == 0 {
+		// The line feed moves from the last column of a full row, which leaves the cursor on the next row already
+		return row
+	}
+	return row + 1
+}
+
+// clearRegion erases the lines of the prompt and reports if there were any to erase
+func (h *Prompt) clearRegion() bool {
+	region := h.region
+	if region == nil {
+		return false
+	}
+	h.region = nil
+	out := h.getOutputWriter()
+	fmt.Fprint(out, "\r")
+	if region.lines > 0 {
+		fmt.Fprintf(out, ansiCursorUpTpl, region.lines)
+	}
+	fmt.Fprint(out, ansiClearScreenDown)
+	return true
+}
+
+// getRawOutputWriter returns the output writer without the wrappers added by getOutputWriter
+func (h *Prompt) getRawOutputWriter() io.Writer {
+	if h.outputWriter != nil {
+		return h.outputWriter
+	}
+	return defaultOutputWriter
+}
+
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
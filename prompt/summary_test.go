@@ -0,0 +1,103 @@
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestScreenRegion_count(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   int
+	}{
+		{name: "Prompt", output: "Name: ", want: 0},
+		{name: "Line feeds", output: "Name: \r\n\nInvalid Input [x]\n\nName: ", want: 4},
+		{name: "Cursor up", output: "\r\n> a\r\n  b\x1b[2A\rName: ", want: 0},
+		{name: "Cursor up without count", output: "\n\x1b[A", want: 0},
+		{name: "Other sequences", output: "\x1b[1mName\x1b[0m\x1b[2K\n", want: 1},
+		{name: "Never negative", output: "\x1b[5A", want: 0},
+		{name: "Cursor down", output: "\x1b[2B\r\n", want: 3},
+		{name: "Saved cursor", output: "\n\x1b7\x1b[B\rOK\x1b[K\x1b8", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &screenRegion{}
+			r.count([]byte(tt.output))
+			if r.lines != tt.want {
+				t.Errorf("count() = %v, want %v", r.lines, tt.want)
+			}
+		})
+	}
+}
+
+func Test_echoedRows(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		cols int
+		want int
+	}{
+		{name: "Unknown width", text: strings.Repeat("x", 100), cols: 0, want: 1},
+		{name: "Empty", text: "", cols: 10, want: 1},
+		{name: "Fits", text: "Name: Bob", cols: 10, want: 1},
+		{name: "Fills the row", text: "Name: Bobb", cols: 10, want: 1},
+		{name: "Wraps", text: "Name: Bobby", cols: 10, want: 2},
+		{name: "Wraps twice", text: "Name: " + strings.Repeat("x", 20), cols: 10, want: 3},
+		{name: "Escape sequences", text: "\x1b[1mName\x1b[0m: Bob", cols: 10, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := echoedRows(tt.text, tt.cols); got != tt.want {
+				t.Errorf("echoedRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompt_AnsweredSummary(t *testing.T) {
+	t.Run("Not a terminal", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		p := &Prompt{PromptMessage: "Name"}
+		p.SetOptions(WithAnsweredSummary(), WithReader(strings.NewReader("Bob\n")), WithWriter(out))
+		if _, err := p.Show(); err != nil {
+			t.Fatalf("Show() error = %v", err)
+		}
+		if out.String() != "Name: " {
+			t.Errorf("Show() output = %q, want the prompt only", out.String())
+		}
+	})
+	t.Run("Cleared", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		p := &Prompt{PromptMessage: "Password", IsPassword: true}
+		p.SetOptions(WithAnsweredSummary(), WithReader(strings.NewReader("\nhunter2\n")), WithWriter(out))
+		// Simulate a terminal, whose region is started by beginRegion
+		p.initializeScanner()
+		p.region = &screenRegion{}
+		p.showPrompt()
+		p.readInput()
+		p.displayInvalidInputMessage("")
+		p.showPrompt()
+		p.readInput()
+		p.displaySummary("hunter2")
+		if want := "\r\x1b[5A\x1b[J✔ Password: ********\n"; !strings.HasSuffix(out.String(), want) {
+			t.Errorf("displaySummary() output = %q, want suffix %q", out.String(), want)
+		}
+	})
+	t.Run("Wrapped error", func(t *testing.T) {
+		out := new(bytes.Buffer)
+		p := &Prompt{PromptMessage: "Name", InvalidInputMessage: "Names must start with a capital letter"}
+		p.SetOptions(WithAnsweredSummary(), WithTerminalWidth(20), WithReader(strings.NewReader("bob\n")), WithWriter(out))
+		p.initializeScanner()
+		p.region = &screenRegion{}
+		p.showPrompt()
+		p.displayInvalidInputMessage("bob")
+		p.showPrompt()
+		p.displaySummary("Bob")
+		// Two blank lines and a message wrapped over three rows, each ended by a line feed
+		if want := "\r\x1b[5A\x1b[J✔ Name: Bob\n"; !strings.HasSuffix(out.String(), want) {
+			t.Errorf("displaySummary() output = %q, want suffix %q", out.String(), want)
+		}
+	})
+}
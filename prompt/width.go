@@ -0,0 +1,293 @@
+package prompt
+
+import (
+	"golang.org/x/term"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	zeroWidthJoiner   = 0x200d
+	variationSelector = 0xfe0f // Requests emoji presentation of the preceding rune
+)
+
+// wideRanges are the East Asian Wide and Fullwidth ranges, and the emoji ranges displayed in two columns
+var wideRanges = []struct{ lo, hi rune }{
+	{0x1100, 0x115f},   // Hangul Jamo initial consonants
+	{0x231a, 0x231b},   // Watch, hourglass
+	{0x2329, 0x232a},   // Angle brackets
+	{0x23e9, 0x23ec},   // Media buttons
+	{0x23f0, 0x23f0},   // Alarm clock
+	{0x23f3, 0x23f3},   // Hourglass
+	{0x25fd, 0x25fe},   // Medium small squares
+	{0x2614, 0x2615},   // Umbrella, hot beverage
+	{0x2648, 0x2653},   // Zodiac
+	{0x267f, 0x267f},   // Wheelchair
+	{0x2693, 0x2693},   // Anchor
+	{0x26a1, 0x26a1},   // High voltage
+	{0x26aa, 0x26ab},   // Circles
+	{0x26bd, 0x26be},   // Soccer ball, baseball
+	{0x26c4, 0x26c5},   // Snowman, sun behind cloud
+	{0x26ce, 0x26ce},   // Ophiuchus
+	{0x26d4, 0x26d4},   // No entry
+	{0x26ea, 0x26ea},   // Church
+	{0x26f2, 0x26f3},   // Fountain, golf
+	{0x26f5, 0x26f5},   // Sailboat
+	{0x26fa, 0x26fa},   // Tent
+	{0x26fd, 0x26fd},   // Fuel pump
+	{0x2705, 0x2705},   // White heavy check mark
+	{0x270a, 0x270b},   // Raised fists
+	{0x2728, 0x2728},   // Sparkles
+	{0x274c, 0x274c},   // Cross mark
+	{0x274e, 0x274e},   // Negative squared cross mark
+	{0x2753, 0x2755},   // Question and exclamation marks
+	{0x2757, 0x2757},   // Heavy exclamation mark
+	{0x2795, 0x2797},   // Heavy plus, minus, division
+	{0x27b0, 0x27b0},   // Curly loop
+	{0x27bf, 0x27bf},   // Double curly loop
+	{0x2b1b, 0x2b1c},   // Large squares
+	{0x2b50, 0x2b50},   // Star
+	{0x2b55, 0x2b55},   // Heavy large circle
+	{0x2e80, 0x303e},   // CJK radicals, symbols and punctuation
+	{0x3041, 0x33ff},   // Hiragana, Katakana, Bopomofo, Hangul compatibility Jamo, CJK compatibility
+	{0x3400, 0x4dbf},   // CJK unified ideographs extension A
+	{0x4e00, 0x9fff},   // CJK unified ideographs
+	{0xa000, 0xa4cf},   // Yi
+	{0xa960, 0xa97f},   // Hangul Jamo extended A
+	{0xac00, 0xd7a3},   // Hangul syllables
+	{0xf900, 0xfaff},   // CJK compatibility ideographs
+	{0xfe10, 0xfe19},   // Vertical forms
+	{0xfe30, 0xfe6f},   // CJK compatibility forms, small form variants
+	{0xff00, 0xff60},   // Fullwidth forms
+	{0xffe0, 0xffe6},   // Fullwidth signs
+	{0x16fe0, 0x16fe4}, // Ideographic symbols
+	{0x17000, 0x18cff}, // Tangut
+	{0x1b000, 0x1b2ff}, // Kana supplement and extensions, Nushu
+	{0x1f004, 0x1f004}, // Mahjong tile red dragon
+	{0x1f0cf, 0x1f0cf}, // Playing card black joker
+	{0x1f18e, 0x1f18e}, // Negative squared AB
+	{0x1f191, 0x1f19a}, // Squared CL to VS
+	{0x1f200, 0x1f2ff}, // Enclosed ideographic supplement
+	{0x1f300, 0x1f64f}, // Miscellaneous symbols and pictographs, emoticons
+	{0x1f680, 0x1f6ff}, // Transport and map symbols
+	{0x1f7e0, 0x1f7eb}, // Colored circles and squares
+	{0x1f90c, 0x1f9ff}, // Supplemental symbols and pictographs
+	{0x1fa70, 0x1faff}, // Symbols and pictographs extended A
+	{0x20000, 0x2fffd}, // CJK unified ideographs extension B and later
+	{0x30000, 0x3fffd}, // CJK unified ideographs extension G and later
+}
+
+// runeWidth returns the number of terminal columns r takes: 0 for combining marks, control and zero width
+// characters, 2 for East Asian wide and fullwidth characters and emoji, otherwise 1
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) || r == zeroWidthJoiner || r == variationSelector:
+		return 0
+	}
+	for _, w := range wideRanges {
+		if r < w.lo {
+			return 1
+		}
+		if r <= w.hi {
+			return 2
+		}
+	}
+	return 1
+}
+
+// stringWidth returns the number of terminal columns s takes, skipping ANSI escape sequences
+func stringWidth(s string) int {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := escapeSequenceLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		width += runeWidth(r)
+		i += size
+	}
+	return width
+}
+
+// runesWidth returns the number of terminal columns of runes
+func runesWidth(runes []rune) int {
+	width := 0
+	for _, r := range runes {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// escapeSequenceLength returns the length of the ANSI escape sequence s starts with, or 0 if it doesn't start with
+// one. CSI sequences, ie: "\x1b[1;31m", and two byte sequences, ie: "\x1b7", are recognized
+func escapeSequenceLength(s string) int {
+	if len(s) < 2 || s[0] != ansiEscape {
+		return 0
+	}
+	if s[1] != '[' {
+		return 2
+	}
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// wrapText wraps every line of s at word boundaries so no line is wider than cols columns. Words wider than cols are
+// broken; Escape sequences don't take up columns. s is returned as is if cols isn't positive
+func wrapText(s string, cols int) string {
+	if cols <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = wrapLine(line, cols)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func wrapLine(line string, cols int) string {
+	if stringWidth(line) <= cols {
+		return line
+	}
+	var (
+		b     strings.Builder
+		width int // Width of the current row
+	)
+	words := strings.SplitAfter(line, " ")
+	for _, word := range words {
+		if word == "" {
+			continue
+		}
+		trimmed := strings.TrimSuffix(word, " ")
+		w := stringWidth(trimmed)
+		if width > 0 && width+w > cols {
+			// Replace the space ending the previous word with the line break
+			str := strings.TrimSuffix(b.String(), " ")
+			b.Reset()
+			b.WriteString(str + "\n")
+			width = 0
+		}
+		for w > cols {
+			head, rest := splitAtWidth(trimmed, cols-width)
+			b.WriteString(head + "\n")
+			trimmed, width = rest, 0
+			w = stringWidth(trimmed)
+		}
+		b.WriteString(trimmed)
+		width += w
+		if strings.HasSuffix(word, " ") {
+			b.WriteString(" ")
+			width++
+		}
+	}
+	return b.String()
+}
+
+// splitAtWidth splits s after the runes which fit in cols columns, keeping escape sequences with the head
+func splitAtWidth(s string, cols int) (string, string) {
+	width := 0
+	for i := 0; i < len(s); {
+		if n := escapeSequenceLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if width+runeWidth(r) > cols && i > 0 {
+			return s[:i], s[i:]
+		}
+		width += runeWidth(r)
+		i += size
+	}
+	return s, ""
+}
+
+// cursorPosition returns the row and column, counted from 0, of the cursor after writing s from the first column of
+// a terminal cols wide. A wide rune which doesn't fit in the rest of a row wraps to the next, and text ending at the
+// last column places the cursor on the next row, where the following output goes
+func cursorPosition(s string, cols int) (int, int) {
+	row, col := 0, 0
+	for i := 0; i < len(s); {
+		if n := escapeSequenceLength(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		w := runeWidth(r)
+		if col+w > cols {
+			row, col = row+1, 0
+		}
+		col += w
+	}
+	if col >= cols {
+		return row + 1, 0
+	}
+	return row, col
+}
+
+// terminalWidths caches the width of terminals by file descriptor; The cache is cleared when a terminal is resized.
+// Widths are only cached on platforms signalling resizes
+var terminalWidths = struct {
+	sync.Mutex
+	widths map[uintptr]int
+	watch  sync.Once
+	cached bool
+}{widths: make(map[uintptr]int)}
+
+// terminalWidth returns the number of columns of w, or 0 if w isn't a terminal
+func terminalWidth(w io.Writer) int {
+	f, ok := w.(*os.File)
+	if !ok {
+		return 0
+	}
+	terminalWidths.watch.Do(func() {
+		terminalWidths.cached = watchResize(clearTerminalWidths)
+	})
+	if !terminalWidths.cached {
+		return queryTerminalWidth(f)
+	}
+	terminalWidths.Lock()
+	defer terminalWidths.Unlock()
+	if width, ok := terminalWidths.widths[f.Fd()]; ok {
+		return width
+	}
+	width := queryTerminalWidth(f)
+	terminalWidths.widths[f.Fd()] = width
+	return width
+}
+
+// queryTerminalWidth returns the number of columns of the terminal f, or 0 if it isn't one
+func queryTerminalWidth(f *os.File) int {
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
+
+func clearTerminalWidths() {
+	terminalWidths.Lock()
+	defer terminalWidths.Unlock()
+	terminalWidths.widths = make(map[uintptr]int)
+}
+
+// getTerminalWidth returns the width set with SetOption(WithTerminalWidth), or the width of the output terminal; 0 if
+// the output isn't a terminal, so text isn't wrapped
+func (h *Prompt) getTerminalWidth() int {
+	if h.terminalWidth > 0 {
+		return h.terminalWidth
+	}
+	return terminalWidth(h.getRawOutputWriter())
+}
@@ -0,0 +1,120 @@
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		r    rune
+		want int
+	}{
+		{name: "ASCII", r: 'a', want: 1},
+		{name: "Latin", r: 'é', want: 1},
+		{name: "Control", r: '\t', want: 0},
+		{name: "Combining acute accent", r: '́', want: 0},
+		{name: "Zero width joiner", r: '‍', want: 0},
+		{name: "Variation selector", r: '️', want: 0},
+		{name: "Kanji", r: '漢', want: 2},
+		{name: "Hiragana", r: 'ひ', want: 2},
+		{name: "Hangul", r: '한', want: 2},
+		{name: "Fullwidth", r: 'Ａ', want: 2},
+		{name: "Emoji", r: '😀', want: 2},
+		{name: "Check mark", r: '✔', want: 1},
+		{name: "Box drawing", r: '─', want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runeWidth(tt.r); got != tt.want {
+				t.Errorf("runeWidth(%q) = %v, want %v", tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+	}{
+		{name: "Empty", s: "", want: 0},
+		{name: "ASCII", s: "Name: ", want: 6},
+		{name: "Combining", s: "été", want: 3},
+		{name: "CJK", s: "名前: ", want: 6},
+		{name: "Emoji sequence", s: "👍🏽", want: 4},
+		{name: "Escape sequences", s: "\x1b[1;31mName\x1b[0m\x1b7\x1b8", want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stringWidth(tt.s); got != tt.want {
+				t.Errorf("stringWidth(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		cols int
+		want string
+	}{
+		{name: "Unknown width", s: "Enter your full name", cols: 0, want: "Enter your full name"},
+		{name: "Fits", s: "Name: ", cols: 10, want: "Name: "},
+		{name: "Word boundaries", s: "Enter your full name: ", cols: 10, want: "Enter your\nfull name: "},
+		{name: "Long word", s: "Supercalifragilistic: ", cols: 8, want: "Supercal\nifragili\nstic: "},
+		{name: "Lines", s: "First line\nSecond line", cols: 6, want: "First\nline\nSecond\nline"},
+		{name: "Wide runes", s: "お名前を入力してください: ", cols: 10, want: "お名前を入\n力してくだ\nさい: "},
+		{name: "Wide rune doesn't fit", s: "aお名前", cols: 4, want: "aお\n名前"},
+		{name: "Escape sequences", s: "\x1b[1mEnter your\x1b[0m name: ", cols: 10, want: "\x1b[1mEnter your\x1b[0m\nname: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.s, tt.cols); got != tt.want {
+				t.Errorf("wrapText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorPosition(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		cols    int
+		wantRow int
+		wantCol int
+	}{
+		{name: "Empty", s: "", cols: 10, wantRow: 0, wantCol: 0},
+		{name: "First row", s: "Name: ab", cols: 10, wantRow: 0, wantCol: 8},
+		{name: "Last column", s: "Name: abcd", cols: 10, wantRow: 1, wantCol: 0},
+		{name: "Wrapped", s: "Name: abcdef", cols: 10, wantRow: 1, wantCol: 2},
+		{name: "Wide rune wraps early", s: "Name: abc漢", cols: 10, wantRow: 1, wantCol: 2},
+		{name: "Escape sequences", s: "\x1b[1mName\x1b[0m: ", cols: 10, wantRow: 0, wantCol: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, col := cursorPosition(tt.s, tt.cols)
+			if row != tt.wantRow || col != tt.wantCol {
+				t.Errorf("cursorPosition() = %v, %v, want %v, %v", row, col, tt.wantRow, tt.wantCol)
+			}
+		})
+	}
+}
+
+func TestPrompt_WrappedPromptMessage(t *testing.T) {
+	out := new(bytes.Buffer)
+	p := &Prompt{PromptMessage: "Enter the name of the new project", DefaultAsString: "demo"}
+	p.SetOptions(WithReader(strings.NewReader("\n")), WithWriter(out), WithTerminalWidth(20))
+	if _, err := p.Show(); err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if want := "Enter the name of\nthe new project\n[demo]: "; out.String() != want {
+		t.Errorf("Show() output = %q, want %q", out.String(), want)
+	}
+}
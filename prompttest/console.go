@@ -0,0 +1,321 @@
+// Package prompttest provides a scripted console for unit testing interactive flows built with the prompt package
+package prompttest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/prompt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var (
+	// ErrDeviated is returned to the prompt under test once the conversation no longer matches the script
+	ErrDeviated = errors.New("prompttest: conversation deviated from script")
+)
+
+type expectKind int
+
+const (
+	expectContains expectKind = iota
+	expectPrompt
+	expectError
+	expectOutput
+	expectRegexp
+)
+
+type expectation struct {
+	kind expectKind
+	text string
+	re   *regexp.Regexp
+}
+
+func (e expectation) matches(rendered string) bool {
+	switch e.kind {
+	case expectPrompt:
+		return strings.HasSuffix(rendered, e.text)
+	case expectOutput:
+		return rendered == e.text
+	case expectRegexp:
+		return e.re.MatchString(rendered)
+	default:
+		return strings.Contains(rendered, e.text)
+	}
+}
+
+func (e expectation) String() string {
+	switch e.kind {
+	case expectPrompt:
+		return fmt.Sprintf("expected prompt %q", e.text)
+	case expectError:
+		return fmt.Sprintf("expected error message %q", e.text)
+	case expectOutput:
+		return fmt.Sprintf("expected output %q", e.text)
+	case expectRegexp:
+		return fmt.Sprintf("expected output matching %q", e.re.String())
+	default:
+		return fmt.Sprintf("expected output containing %q", e.text)
+	}
+}
+
+func (e expectation) want() string {
+	if e.kind == expectRegexp {
+		return e.re.String()
+	}
+	return e.text
+}
+
+// step is everything rendered between two inputs: The expectations on the rendered text followed by the input to send
+type step struct {
+	expect    []expectation
+	input     string
+	eof       bool
+	hasAction bool
+}
+
+// Console is a scripted stand-in for a user sitting at a terminal. It implements both io.Reader and io.Writer and
+// is attached to a Prompt or PromptList with Options(). Every time the prompt reads input, everything written since
+// the previous input is checked against the expectations of the next step, then that step's input is delivered
+type Console struct {
+	tb         testing.TB
+	mu         sync.Mutex
+	steps      []*step
+	current    int
+	started    bool
+	remaining  string
+	output     strings.Builder
+	stepStart  int
+	transcript []string
+	deviated   bool
+}
+
+// New returns an empty Console which reports deviations from its script to tb
+func New(tb testing.TB) *Console {
+	return &Console{tb: tb}
+}
+
+// Options returns the prompt options which attach the Console to a Prompt or PromptList
+func (c *Console) Options() []prompt.Opt {
+	return []prompt.Opt{prompt.WithReader(c), prompt.WithWriter(c)}
+}
+
+// LineEditorOptions returns the prompt options which attach the Console and read input with the line editor, as a
+// real terminal would, so keys sent with SendKeys are interpreted
+func (c *Console) LineEditorOptions() []prompt.Opt {
+	return append(c.Options(), prompt.WithLineEditor())
+}
+
+// Expect asserts the text rendered before the next input contains text
+func (c *Console) Expect(text string) *Console {
+	return c.addExpectation(expectation{kind: expectContains, text: text})
+}
+
+// ExpectPrompt asserts the text rendered before the next input ends with the prompt text, ie: "Name: "
+func (c *Console) ExpectPrompt(text string) *Console {
+	return c.addExpectation(expectation{kind: expectPrompt, text: text})
+}
+
+// ExpectError asserts the error message msg was rendered since the previous input
+func (c *Console) ExpectError(msg string) *Console {
+	return c.addExpectation(expectation{kind: expectError, text: msg})
+}
+
+// ExpectOutput asserts the text rendered before the next input is exactly text
+func (c *Console) ExpectOutput(text string) *Console {
+	return c.addExpectation(expectation{kind: expectOutput, text: text})
+}
+
+// ExpectRegexp asserts the text rendered before the next input matches re
+func (c *Console) ExpectRegexp(re *regexp.Regexp) *Console {
+	return c.addExpectation(expectation{kind: expectRegexp, re: re})
+}
+
+// SendLine queues s followed by Enter as the answer to the current step
+func (c *Console) SendLine(s string) *Console {
+	return c.addAction(s+string(KeyEnter), false)
+}
+
+// Send queues raw as the input of the current step, exactly as given
+func (c *Console) Send(raw string) *Console {
+	return c.addAction(raw, false)
+}
+
+// SendKeys queues the key presses as the input of the current step
+func (c *Console) SendKeys(keys ...Key) *Console {
+	return c.addAction(joinKeys(keys), false)
+}
+
+// SendEOF closes the input at the current step, as if the user pressed Ctrl-D on an empty line
+func (c *Console) SendEOF() *Console {
+	return c.addAction("", true)
+}
+
+// Output returns everything written to the Console so far
+func (c *Console) Output() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.output.String()
+}
+
+// Transcript returns the conversation so far; Rendered lines are prefixed with "<" and inputs with ">"
+func (c *Console) Transcript() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.transcriptLocked()
+}
+
+// Done asserts every step of the script was played. Expectations queued after the final input are checked
+// against the output rendered since that input
+func (c *Console) Done() {
+	c.tb.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.deviated {
+		return
+	}
+	if c.started && c.remaining != "" {
+		c.fail(fmt.Sprintf("step %d: input %q was not completely read", c.current+1, c.remaining), "", "")
+		return
+	}
+	for c.current < len(c.steps) {
+		s := c.steps[c.current]
+		if s.hasAction {
+			c.fail(fmt.Sprintf("step %d: prompt never read input %q", c.current+1, s.describeInput()), "", "")
+			return
+		}
+		if !c.checkStep(s) {
+			return
+		}
+		c.current++
+	}
+}
+
+// Read implements io.Reader for the prompt under test
+func (c *Console) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for !c.deviated {
+		if c.current >= len(c.steps) || !c.steps[c.current].hasAction {
+			c.fail(fmt.Sprintf("step %d: prompt is waiting for input but the script has no more", c.current+1), "", c.rendered())
+			break
+		}
+		s := c.steps[c.current]
+		if !c.started {
+			if !c.checkStep(s) {
+				break
+			}
+			c.started = true
+			c.remaining = s.input
+			c.transcript = append(c.transcript, "> "+s.describeInput())
+			if s.eof {
+				c.advance()
+				return 0, io.EOF
+			}
+		}
+		if c.remaining == "" {
+			c.advance()
+			continue
+		}
+		n := copy(p, c.remaining)
+		c.remaining = c.remaining[n:]
+		if c.remaining == "" {
+			c.advance()
+		}
+		return n, nil
+	}
+	return 0, ErrDeviated
+}
+
+// Write implements io.Writer for the prompt under test
+func (c *Console) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.output.Write(p)
+}
+
+func (c *Console) addExpectation(e expectation) *Console {
+	s := c.openStep()
+	s.expect = append(s.expect, e)
+	return c
+}
+
+func (c *Console) addAction(input string, eof bool) *Console {
+	s := c.openStep()
+	s.input = input
+	s.eof = eof
+	s.hasAction = true
+	return c
+}
+
+// openStep returns the last step if it is still collecting expectations, otherwise starts a new one
+func (c *Console) openStep() *step {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.steps) == 0 || c.steps[len(c.steps)-1].hasAction {
+		c.steps = append(c.steps, new(step))
+	}
+	return c.steps[len(c.steps)-1]
+}
+
+func (c *Console) rendered() string {
+	return c.output.String()[c.stepStart:]
+}
+
+func (c *Console) checkStep(s *step) bool {
+	rendered := c.rendered()
+	c.transcript = append(c.transcript, transcriptOutput(rendered)...)
+	for _, e := range s.expect {
+		if !e.matches(rendered) {
+			c.fail(fmt.Sprintf("step %d: %v", c.current+1, e), e.want(), rendered)
+			return false
+		}
+	}
+	c.stepStart = c.output.Len()
+	return true
+}
+
+func (c *Console) advance() {
+	c.current++
+	c.started = false
+	c.remaining = ""
+}
+
+func (c *Console) fail(msg, want, got string) {
+	c.deviated = true
+	report := "prompttest: " + msg + "\n"
+	if want != "" || got != "" {
+		report += "diff (- expected, + rendered):\n" + diffLines(want, got)
+	}
+	report += "transcript:\n" + c.transcriptLocked()
+	c.tb.Errorf("%s", report)
+}
+
+func (c *Console) transcriptLocked() string {
+	var sb strings.Builder
+	for _, l := range c.transcript {
+		sb.WriteString("  " + l + "\n")
+	}
+	return sb.String()
+}
+
+func (s *step) describeInput() string {
+	if s.eof {
+		return "<EOF>"
+	}
+	return strconv.Quote(s.input)
+}
+
+func transcriptOutput(rendered string) []string {
+	if rendered == "" {
+		return nil
+	}
+	var lines []string
+	for _, l := range strings.Split(rendered, "\n") {
+		lines = append(lines, "< "+l)
+	}
+	return lines
+}
@@ -0,0 +1,228 @@
+package prompttest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/prompt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// recordingTB captures reported failures instead of failing the enclosing test
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func makeAgePrompt(c *Console) *prompt.Prompt {
+	p := &prompt.Prompt{
+		PromptMessage: "Age",
+		InputValidatorFunc: func(s string) bool {
+			_, err := strconv.Atoi(s)
+			return err == nil
+		},
+	}
+	p.SetOptions(c.Options()...)
+	return p
+}
+
+func TestConsole_ScriptedConversation(t *testing.T) {
+	c := New(t)
+	c.ExpectOutput("Age: ").SendLine("old")
+	c.ExpectError("Invalid Input [old]").ExpectPrompt("Age: ").SendLine("42")
+
+	got, err := makeAgePrompt(c).Show()
+	c.Done()
+
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	if got != "42" {
+		t.Errorf("Show() = %v, want %v", got, "42")
+	}
+	wantTranscript := "  < Age: \n  > \"old\\n\"\n  < \n  < Invalid Input [old]\n  < \n  < Age: \n  > \"42\\n\"\n"
+	if c.Transcript() != wantTranscript {
+		t.Errorf("Transcript() = %q, want %q", c.Transcript(), wantTranscript)
+	}
+}
+
+func TestConsole_PromptList(t *testing.T) {
+	c := New(t)
+	c.ExpectPrompt("Name: ").SendLine("Bob")
+	c.ExpectPrompt("City: ").SendKeys("Paris", KeyEnter)
+
+	l := prompt.MakePromptList(
+		prompt.Prompt{PromptMessage: "Name", MapKey: "name"},
+		prompt.Prompt{PromptMessage: "City", MapKey: "city"},
+	)
+	l.SetOptions(c.Options()...)
+	got, err := l.Show()
+	c.Done()
+
+	if err != nil {
+		t.Fatalf("Show() error = %v", err)
+	}
+	want := map[string]interface{}{"name": "Bob", "city": "Paris"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Show() = %v, want %v", got, want)
+	}
+}
+
+func TestConsole_LineEditorKeys(t *testing.T) {
+	c := New(t)
+	c.ExpectPrompt("Name: ").SendKeys("Bb", KeyLeft, "o", KeyEnter)
+	c.ExpectPrompt("Name: ").SendKeys("Bob", KeyCtrlC)
+
+	p := &prompt.Prompt{PromptMessage: "Name"}
+	p.SetOptions(c.LineEditorOptions()...)
+	got, err := p.Show()
+	if err != nil || got != "Bob" {
+		t.Errorf("Show() = %v, %v, want %v", got, err, "Bob")
+	}
+	if _, err := p.Show(); !errors.Is(err, prompt.ErrInterrupted) {
+		t.Errorf("Show() error = %v, want %v", err, prompt.ErrInterrupted)
+	}
+	c.Done()
+}
+
+func TestConsole_SendEOF(t *testing.T) {
+	c := New(t)
+	c.ExpectPrompt("Age: ").SendEOF()
+
+	got, err := makeAgePrompt(c).Show()
+	c.Done()
+
+	if err == nil {
+		t.Errorf("Show() = %v, want error", got)
+	}
+}
+
+func TestConsole_Deviations(t *testing.T) {
+	tests := []struct {
+		name       string
+		script     func(c *Console)
+		wantReport *regexp.Regexp
+	}{
+		{
+			name: "Wrong prompt",
+			script: func(c *Console) {
+				c.ExpectPrompt("Name: ").SendLine("Bob")
+			},
+			wantReport: regexp.MustCompile(`(?s)step 1: expected prompt "Name: ".*- Name: \n\+ Age: .*transcript:\n  < Age: `),
+		},
+		{
+			name: "Missing error message",
+			script: func(c *Console) {
+				c.ExpectPrompt("Age: ").SendLine("x")
+				c.ExpectError("Too old").SendLine("1")
+			},
+			wantReport: regexp.MustCompile(`(?s)step 2: expected error message "Too old".*> "x\\n"`),
+		},
+		{
+			name: "Script exhausted",
+			script: func(c *Console) {
+				c.ExpectPrompt("Age: ").SendLine("x")
+			},
+			wantReport: regexp.MustCompile(`step 2: prompt is waiting for input but the script has no more`),
+		},
+		{
+			name: "Regexp mismatch",
+			script: func(c *Console) {
+				c.ExpectRegexp(regexp.MustCompile(`^Name`)).SendLine("1")
+			},
+			wantReport: regexp.MustCompile(`step 1: expected output matching "\^Name"`),
+		},
+		{
+			name: "Contains mismatch",
+			script: func(c *Console) {
+				c.Expect("Years").SendLine("1")
+			},
+			wantReport: regexp.MustCompile(`step 1: expected output containing "Years"`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			c := New(tb)
+			tt.script(c)
+
+			_, err := makeAgePrompt(c).Show()
+			c.Done()
+
+			if err == nil {
+				t.Errorf("Show() error = nil, want error")
+			}
+			if len(tb.errors) != 1 {
+				t.Fatalf("got %d reported errors, want 1: %v", len(tb.errors), tb.errors)
+			}
+			if !tt.wantReport.MatchString(tb.errors[0]) {
+				t.Errorf("report = %q, want match on %v", tb.errors[0], tt.wantReport)
+			}
+		})
+	}
+}
+
+func TestConsole_Done(t *testing.T) {
+	tests := []struct {
+		name       string
+		script     func(c *Console)
+		wantReport string
+	}{
+		{
+			name: "Unplayed step",
+			script: func(c *Console) {
+				c.SendLine("Bob")
+			},
+			wantReport: `step 1: prompt never read input "\"Bob\\n\""`,
+		},
+		{
+			name: "Trailing expectation",
+			script: func(c *Console) {
+				c.Expect("Goodbye")
+			},
+			wantReport: `step 1: expected output containing "Goodbye"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			c := New(tb)
+			tt.script(c)
+			c.Write([]byte("Hello"))
+			c.Done()
+
+			if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], tt.wantReport) {
+				t.Errorf("reported errors = %q, want %q", tb.errors, tt.wantReport)
+			}
+		})
+	}
+}
+
+func TestConsole_ReadSplitsInput(t *testing.T) {
+	c := New(t)
+	c.SendKeys(KeyUp, KeyEnter)
+
+	buf := make([]byte, 2)
+	var got string
+	for i := 0; i < 2; i++ {
+		n, err := c.Read(buf)
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		got += string(buf[:n])
+	}
+	c.Done()
+
+	if got != string(KeyUp+KeyEnter) {
+		t.Errorf("Read() = %q, want %q", got, KeyUp+KeyEnter)
+	}
+}
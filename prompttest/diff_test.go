@@ -0,0 +1,38 @@
+package prompttest
+
+import "testing"
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		got  string
+		diff string
+	}{
+		{
+			name: "Equal",
+			want: "a\nb",
+			got:  "a\nb",
+			diff: "  a\n  b\n",
+		},
+		{
+			name: "Changed line",
+			want: "a\nb\nc",
+			got:  "a\nx\nc",
+			diff: "  a\n- b\n+ x\n  c\n",
+		},
+		{
+			name: "Added and removed",
+			want: "a\nb",
+			got:  "b\nc",
+			diff: "- a\n  b\n+ c\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffLines(tt.want, tt.got); got != tt.diff {
+				t.Errorf("diffLines() = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package prompttest
+
+// Key is the raw byte sequence a terminal sends for a single key press
+type Key string
+
+// KeyEnter ends a line of input of any prompt
+const KeyEnter Key = "\n"
+
+// Keys understood by the line editor, used on a terminal or with prompt.WithLineEditor, and by Select; All are sent
+// verbatim to the prompt's io.Reader. Prompts reading plain lines receive them as part of the line
+const (
+	KeyTab       Key = "\t"
+	KeyBackspace Key = "\x7f"
+	KeyEscape    Key = "\x1b"
+	KeyUp        Key = "\x1b[A"
+	KeyDown      Key = "\x1b[B"
+	KeyRight     Key = "\x1b[C"
+	KeyLeft      Key = "\x1b[D"
+	KeyHome      Key = "\x1b[H"
+	KeyEnd       Key = "\x1b[F"
+	KeyDelete    Key = "\x1b[3~"
+	KeyAltB      Key = "\x1bb"
+	KeyAltF      Key = "\x1bf"
+	KeyAltY      Key = "\x1by"
+	KeyCtrlA     Key = "\x01"
+	KeyCtrlC     Key = "\x03"
+	KeyCtrlD     Key = "\x04"
+	KeyCtrlE     Key = "\x05"
+	KeyCtrlK     Key = "\x0b"
+	KeyCtrlR     Key = "\x12"
+	KeyCtrlU     Key = "\x15"
+	KeyCtrlW     Key = "\x17"
+	KeyCtrlY     Key = "\x19"
+)
+
+func joinKeys(keys []Key) string {
+	var s string
+	for _, k := range keys {
+		s += string(k)
+	}
+	return s
+}
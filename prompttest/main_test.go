@@ -0,0 +1,14 @@
+package prompttest
+
+import (
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"os"
+	"testing"
+)
+
+// TestMain pins built-in messages to English, whatever the locale of the environment running the tests, as the tests
+// expect the English invalid input message in the prompt output
+func TestMain(m *testing.M) {
+	i18n.SetLocale(i18n.FallbackLocale)
+	os.Exit(m.Run())
+}
@@ -0,0 +1,175 @@
+package prompttest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/bchivari/go-cli-prompt/prompt"
+	"golang.org/x/sys/unix"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrTimeout is returned when the pseudo-terminal does not reach the expected state in time
+	ErrTimeout = errors.New("prompttest: timed out waiting on pseudo-terminal")
+)
+
+type ptyResult struct {
+	value interface{}
+	err   error
+}
+
+// PTY is a Linux pseudo-terminal pair. Prompts under test are attached to Terminal, so every real-TTY code path is
+// taken, while the test plays the user on the controlling side with Send and Expect
+type PTY struct {
+	Terminal *os.File // The terminal side the prompt reads from and writes to
+
+	control *os.File
+	mu      sync.Mutex
+	output  bytes.Buffer
+	closed  chan struct{}
+	result  chan ptyResult
+}
+
+// OpenPTY allocates a new pseudo-terminal; Close must be called when done
+func OpenPTY() (*PTY, error) {
+	control, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, err
+	}
+	fd := int(control.Fd())
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		control.Close()
+		return nil, err
+	}
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	if err != nil {
+		control.Close()
+		return nil, err
+	}
+	terminal, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		control.Close()
+		return nil, err
+	}
+	p := &PTY{
+		Terminal: terminal,
+		control:  control,
+		closed:   make(chan struct{}),
+		result:   make(chan ptyResult, 1),
+	}
+	go p.drain()
+	return p, nil
+}
+
+// Options returns the prompt options which attach a Prompt or PromptList to the terminal
+func (p *PTY) Options() []prompt.Opt {
+	return []prompt.Opt{prompt.WithReader(p.Terminal), prompt.WithWriter(p.Terminal)}
+}
+
+// AttachStdin points the process' standard input at the terminal, for code paths which read os.Stdin directly.
+// The returned func restores the original standard input
+func (p *PTY) AttachStdin() (func() error, error) {
+	saved, err := unix.Dup(unix.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	if err := unix.Dup3(int(p.Terminal.Fd()), unix.Stdin, 0); err != nil {
+		unix.Close(saved)
+		return nil, err
+	}
+	return func() error {
+		defer unix.Close(saved)
+		return unix.Dup3(saved, unix.Stdin, 0)
+	}, nil
+}
+
+// Start runs show, typically the Show method of a Prompt attached to the terminal, in its own goroutine
+func (p *PTY) Start(show func() (interface{}, error)) {
+	go func() {
+		value, err := show()
+		p.result <- ptyResult{value: value, err: err}
+	}()
+}
+
+// Wait returns the result of the func passed to Start
+func (p *PTY) Wait(timeout time.Duration) (interface{}, error) {
+	select {
+	case r := <-p.result:
+		return r.value, r.err
+	case <-time.After(timeout):
+		return nil, ErrTimeout
+	}
+}
+
+// Send types s on the terminal
+func (p *PTY) Send(s string) error {
+	_, err := p.control.WriteString(s)
+	return err
+}
+
+// SendKeys types the key presses on the terminal
+func (p *PTY) SendKeys(keys ...Key) error {
+	return p.Send(joinKeys(keys))
+}
+
+// Expect waits until the terminal has displayed text
+func (p *PTY) Expect(text string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for !strings.Contains(p.Output(), text) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: expected %q, terminal shows %q", ErrTimeout, text, p.Output())
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+	return nil
+}
+
+// Output returns everything the terminal has displayed, including the echo of typed input
+func (p *PTY) Output() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.output.String()
+}
+
+// Termios returns the current terminal attributes
+func (p *PTY) Termios() (*unix.Termios, error) {
+	return unix.IoctlGetTermios(int(p.Terminal.Fd()), unix.TCGETS)
+}
+
+// EchoEnabled reports whether the terminal currently echoes typed input
+func (p *PTY) EchoEnabled() (bool, error) {
+	t, err := p.Termios()
+	if err != nil {
+		return false, err
+	}
+	return t.Lflag&unix.ECHO != 0, nil
+}
+
+// Close releases both sides of the pseudo-terminal
+func (p *PTY) Close() error {
+	err := p.Terminal.Close()
+	if cerr := p.control.Close(); err == nil {
+		err = cerr
+	}
+	<-p.closed
+	return err
+}
+
+// drain continuously reads the controlling side so the prompt never blocks writing to a full terminal buffer
+func (p *PTY) drain() {
+	defer close(p.closed)
+	buf := make([]byte, 1024)
+	for {
+		n, err := p.control.Read(buf)
+		p.mu.Lock()
+		p.output.Write(buf[:n])
+		p.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package prompttest
+
+import (
+	"context"
+	"github.com/bchivari/go-cli-prompt/prompt"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+const ptyTimeout = time.Second * 5
+
+func openTestPTY(t *testing.T) *PTY {
+	p, err := OpenPTY()
+	if err != nil {
+		t.Skipf("pseudo-terminals are not available: %v", err)
+	}
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func waitForEcho(t *testing.T, p *PTY, want bool) {
+	deadline := time.Now().Add(ptyTimeout)
+	for {
+		echo, err := p.EchoEnabled()
+		if err != nil {
+			t.Fatalf("EchoEnabled() error = %v", err)
+		}
+		if echo == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("EchoEnabled() = %v, want %v", echo, want)
+		}
+		time.Sleep(time.Millisecond * 5)
+	}
+}
+
+func TestPTY_RegularInputIsEchoed(t *testing.T) {
+	p := openTestPTY(t)
+	namePrompt := &prompt.Prompt{PromptMessage: "Name"}
+	namePrompt.SetOptions(p.Options()...)
+
+	p.Start(namePrompt.Show)
+	if err := p.Expect("Name: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	p.Send("Bob\n")
+	got, err := p.Wait(ptyTimeout)
+
+	if err != nil || got != "Bob" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "Bob")
+	}
+	if err := p.Expect("Name: Bob", ptyTimeout); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPTY_LineEditorRawMode(t *testing.T) {
+	p := openTestPTY(t)
+	namePrompt := &prompt.Prompt{PromptMessage: "Name"}
+	namePrompt.SetOptions(p.Options()...)
+	before, err := p.Termios()
+	if err != nil {
+		t.Fatalf("Termios() error = %v", err)
+	}
+
+	p.Start(namePrompt.Show)
+	if err := p.Expect("Name: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	waitForEcho(t, p, false)
+	p.SendKeys("Bb", KeyLeft, "o", KeyEnd, "!", KeyBackspace, KeyEnter)
+	got, err := p.Wait(ptyTimeout)
+
+	if err != nil || got != "Bob" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "Bob")
+	}
+	if strings.Contains(p.Output(), "^[[D") {
+		t.Errorf("arrow key was echoed: %q", p.Output())
+	}
+	after, err := p.Termios()
+	if err != nil {
+		t.Fatalf("Termios() error = %v", err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("terminal state was not restored: before %+v, after %+v", before, after)
+	}
+}
+
+func TestPTY_CanceledRestoresTerminal(t *testing.T) {
+	tests := []struct {
+		name string
+		show func(ctx context.Context, p *PTY) (interface{}, error)
+	}{
+		{
+			name: "Prompt",
+			show: func(ctx context.Context, p *PTY) (interface{}, error) {
+				namePrompt := &prompt.Prompt{PromptMessage: "Name"}
+				namePrompt.SetOptions(p.Options()...)
+				return namePrompt.ShowWithContext(ctx)
+			},
+		},
+		{
+			name: "Select",
+			show: func(ctx context.Context, p *PTY) (interface{}, error) {
+				s := &prompt.Select{Prompt: prompt.Prompt{PromptMessage: "Name"}, Options: prompt.MakeOptions("Bob", "Alice")}
+				s.SetOptions(p.Options()...)
+				return s.ShowWithContext(ctx)
+			},
+		},
+		{
+			name: "PromptList",
+			show: func(ctx context.Context, p *PTY) (interface{}, error) {
+				list := prompt.MakePromptList(prompt.Prompt{PromptMessage: "Name", MapKey: "name"})
+				list.SetOptions(p.Options()...)
+				return list.ShowWithContext(ctx)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := openTestPTY(t)
+			before, err := p.Termios()
+			if err != nil {
+				t.Fatalf("Termios() error = %v", err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			p.Start(func() (interface{}, error) { return tt.show(ctx, p) })
+			if err := p.Expect("Name: ", ptyTimeout); err != nil {
+				t.Fatal(err)
+			}
+			waitForEcho(t, p, false)
+			cancel()
+			if _, err := p.Wait(ptyTimeout); err == nil {
+				t.Fatalf("ShowWithContext() error = nil, want canceled")
+			}
+
+			after, err := p.Termios()
+			if err != nil {
+				t.Fatalf("Termios() error = %v", err)
+			}
+			if !reflect.DeepEqual(before, after) {
+				t.Errorf("terminal state was not restored: before %+v, after %+v", before, after)
+			}
+			// Answer the abandoned read, which keeps the terminal from being closed
+			p.Send("Bob\r")
+		})
+	}
+}
+
+func TestPTY_PasswordEchoSuppressedAndRestored(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, p *PTY, pw *prompt.Prompt)
+	}{
+		{
+			name: "Terminal io.Reader",
+			setup: func(t *testing.T, p *PTY, pw *prompt.Prompt) {
+				pw.SetOptions(p.Options()...)
+			},
+		},
+		{
+			name: "Stdin",
+			setup: func(t *testing.T, p *PTY, pw *prompt.Prompt) {
+				pw.SetOptions(prompt.WithWriter(p.Terminal))
+				restore, err := p.AttachStdin()
+				if err != nil {
+					t.Skipf("cannot redirect stdin: %v", err)
+				}
+				t.Cleanup(func() { restore() })
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := openTestPTY(t)
+			pw := &prompt.Prompt{PromptMessage: "Password", IsPassword: true}
+			tt.setup(t, p, pw)
+			before, err := p.Termios()
+			if err != nil {
+				t.Fatalf("Termios() error = %v", err)
+			}
+
+			p.Start(pw.Show)
+			if err := p.Expect("Password: ", ptyTimeout); err != nil {
+				t.Fatal(err)
+			}
+			waitForEcho(t, p, false)
+			p.Send("hunter2\n")
+			got, err := p.Wait(ptyTimeout)
+
+			if err != nil || got != "hunter2" {
+				t.Fatalf("Show() = %v, %v, want %v", got, err, "hunter2")
+			}
+			if strings.Contains(p.Output(), "hunter2") {
+				t.Errorf("password was echoed: %q", p.Output())
+			}
+			after, err := p.Termios()
+			if err != nil {
+				t.Fatalf("Termios() error = %v", err)
+			}
+			if !reflect.DeepEqual(before, after) {
+				t.Errorf("terminal state was not restored: before %+v, after %+v", before, after)
+			}
+		})
+	}
+}
+
+func TestPTY_PasswordAfterTypeAhead(t *testing.T) {
+	p := openTestPTY(t)
+	list := prompt.MakePromptList(
+		prompt.Prompt{PromptMessage: "Name", MapKey: "name"},
+		prompt.Prompt{PromptMessage: "Password", MapKey: "password", IsPassword: true},
+		prompt.Prompt{PromptMessage: "Next", MapKey: "next"},
+	)
+	list.SetOptions(p.Options()...)
+
+	p.Start(func() (interface{}, error) { return list.Show() })
+	if err := p.Expect("Name: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	waitForEcho(t, p, false)
+	// The line editor reads the password into the shared buffer along with the name
+	p.Send("Bob\rhunter2\r")
+	if err := p.Expect("Next: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	waitForEcho(t, p, false)
+	p.Send("later\r")
+	got, err := p.Wait(ptyTimeout)
+
+	want := map[string]interface{}{"name": "Bob", "password": "hunter2", "next": "later"}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, want)
+	}
+	if strings.Contains(p.Output(), "hunter2") {
+		t.Errorf("password was echoed: %q", p.Output())
+	}
+}
+
+func TestPTY_ExpectTimeout(t *testing.T) {
+	p := openTestPTY(t)
+	if err := p.Expect("never shown", time.Millisecond*20); err == nil {
+		t.Errorf("Expect() error = nil, want error")
+	}
+	if _, err := p.Wait(time.Millisecond * 20); err != ErrTimeout {
+		t.Errorf("Wait() error = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func TestPTY_MaskedPassword(t *testing.T) {
+	p := openTestPTY(t)
+	pw := &prompt.Prompt{PromptMessage: "Password", IsPassword: true, PasswordMask: '*', ConfirmPassword: true}
+	pw.SetOptions(p.Options()...)
+	before, err := p.Termios()
+	if err != nil {
+		t.Fatalf("Termios() error = %v", err)
+	}
+
+	p.Start(pw.Show)
+	if err := p.Expect("Password: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	waitForEcho(t, p, false)
+	p.SendKeys("hunter22", KeyBackspace, KeyEnter)
+	if err := p.Expect("Password: *******", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Expect("Confirm Password: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	waitForEcho(t, p, false)
+	p.SendKeys("hunter2", KeyEnter)
+	got, err := p.Wait(ptyTimeout)
+
+	if err != nil || got != "hunter2" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "hunter2")
+	}
+	if strings.Contains(p.Output(), "hunter") {
+		t.Errorf("password was echoed: %q", p.Output())
+	}
+	after, err := p.Termios()
+	if err != nil {
+		t.Fatalf("Termios() error = %v", err)
+	}
+	if !reflect.DeepEqual(before, after) {
+		t.Errorf("terminal state was not restored: before %+v, after %+v", before, after)
+	}
+}
+
+func TestPTY_AnsweredSummary(t *testing.T) {
+	p := openTestPTY(t)
+	agePrompt := &prompt.Prompt{PromptMessage: "Age", InputValidatorRegex: regexp.MustCompile(`^\d+$`), SuppressLineEditor: true}
+	agePrompt.SetOptions(append(p.Options(), prompt.WithAnsweredSummary())...)
+
+	p.Start(agePrompt.Show)
+	if err := p.Expect("Age: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	p.Send("x\n")
+	if err := p.Expect("Invalid Input [x]", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	p.Send("42\n")
+	got, err := p.Wait(ptyTimeout)
+
+	if err != nil || got != "42" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "42")
+	}
+	// The echoed "x", the error message and the echoed "42" span 5 lines above the cursor
+	if err := p.Expect("\r\x1b[5A\x1b[J✔ Age: 42\r\n", ptyTimeout); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPTY_SpinnerStoppedByPrompt(t *testing.T) {
+	p := openTestPTY(t)
+	spinner := &prompt.Spinner{Message: "Loading", Frames: []string{"a", "b"}, Interval: time.Millisecond * 5, Writer: p.Terminal}
+	spinner.Start()
+	if err := p.Expect("\rb Loading\x1b[K", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	namePrompt := &prompt.Prompt{PromptMessage: "Name"}
+	namePrompt.SetOptions(p.Options()...)
+
+	p.Start(namePrompt.Show)
+	// The spinner is erased before the prompt is displayed on its line
+	if err := p.Expect("\r\x1b[KName: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	p.Send("Bob\n")
+	if got, err := p.Wait(ptyTimeout); err != nil || got != "Bob" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "Bob")
+	}
+}
+
+func TestPTY_LogWriterAbovePrompt(t *testing.T) {
+	p := openTestPTY(t)
+	// A second file on the same terminal, as os.Stderr is to os.Stdout
+	logFile, err := os.OpenFile(p.Terminal.Name(), os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logFile.Close()
+	logger := log.New(prompt.NewLogWriter(logFile), "", 0)
+	namePrompt := &prompt.Prompt{PromptMessage: "Name"}
+	namePrompt.SetOptions(p.Options()...)
+
+	p.Start(namePrompt.Show)
+	if err := p.Expect("Name: ", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	p.Send("Bo")
+	if err := p.Expect("\rName: Bo\x1b[K", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	logger.Print("connected")
+	if err := p.Expect("\r\x1b[Jconnected\r\n\rName: Bo\x1b[K", ptyTimeout); err != nil {
+		t.Fatal(err)
+	}
+	p.Send("b\r")
+	if got, err := p.Wait(ptyTimeout); err != nil || got != "Bob" {
+		t.Fatalf("Show() = %v, %v, want %v", got, err, "Bob")
+	}
+}
@@ -3,6 +3,10 @@ package validation
 // InputValidator defines a function used to validate a user input string
 type InputValidator func(string) bool
 
+// InputErrorValidator defines a function used to validate a user input string, returning an error which explains
+// why the input is invalid
+type InputErrorValidator func(string) error
+
 // MakeInputValidatorChain can wrap N InputValidator objects into a single InputValidator; Logical AND
 func MakeInputValidatorChain(validators ...InputValidator) InputValidator {
 	chain := func(s string) bool {
@@ -15,3 +19,17 @@ func MakeInputValidatorChain(validators ...InputValidator) InputValidator {
 	}
 	return chain
 }
+
+// MakeInputErrorValidatorChain can wrap N InputErrorValidator objects into a single InputErrorValidator which returns
+// the first error
+func MakeInputErrorValidatorChain(validators ...InputErrorValidator) InputErrorValidator {
+	chain := func(s string) error {
+		for _, v := range validators {
+			if err := v(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return chain
+}
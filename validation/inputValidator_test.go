@@ -1,7 +1,7 @@
 package validation
 
 import (
-	"fmt"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -29,17 +29,45 @@ func TestMakeInputValidatorChain(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			v := MakeInputValidatorChain(tt.args.validators...)
 			if v("hello") {
-				fmt.Errorf("improper validation")
+				t.Errorf("improper validation")
 			}
 			if v("test") {
-				fmt.Errorf("improper validation")
+				t.Errorf("improper validation")
 			}
 			if v("bob") {
-				fmt.Errorf("improper validation")
+				t.Errorf("improper validation")
 			}
 			if !v("bob likes to test") {
-				fmt.Errorf("improper validation")
+				t.Errorf("improper validation")
 			}
 		})
 	}
 }
+
+func TestMakeInputErrorValidatorChain(t *testing.T) {
+	errNoTest, errNoBob := errors.New("no test"), errors.New("no bob")
+	v := MakeInputErrorValidatorChain(func(s string) error {
+		if !strings.Contains(s, "test") {
+			return errNoTest
+		}
+		return nil
+	}, func(s string) error {
+		if !strings.Contains(s, "bob") {
+			return errNoBob
+		}
+		return nil
+	})
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{input: "hello", want: errNoTest},
+		{input: "test", want: errNoBob},
+		{input: "bob likes to test", want: nil},
+	}
+	for _, tt := range tests {
+		if got := v(tt.input); got != tt.want {
+			t.Errorf("validator(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package validation
+
+import (
+	"bufio"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Strength rates how hard a password is to guess
+type Strength int
+
+const (
+	StrengthVeryWeak Strength = iota
+	StrengthWeak
+	StrengthFair
+	StrengthStrong
+	StrengthVeryStrong
+)
+
+// Entropy thresholds in bits of the strength ratings above StrengthVeryWeak
+const (
+	weakEntropyBits       = 28
+	fairEntropyBits       = 36
+	strongEntropyBits     = 60
+	veryStrongEntropyBits = 128
+)
+
+// Sizes of the character pools a password draws from, used to estimate entropy
+const (
+	lowerPoolSize  = 26
+	upperPoolSize  = 26
+	digitPoolSize  = 10
+	symbolPoolSize = 33
+	otherPoolSize  = 100
+)
+
+const (
+	denyListCommentPrefix = "#"
+	predictableRuneWeight = 0.5 // Weight of a rune repeating or continuing a sequence of its predecessor, ie: "aaa" or "123"
+	regularRuneWeight     = 1.0
+)
+
+var strengthMessages = map[Strength]i18n.MessageID{
+	StrengthVeryWeak:   i18n.StrengthVeryWeak,
+	StrengthWeak:       i18n.StrengthWeak,
+	StrengthFair:       i18n.StrengthFair,
+	StrengthStrong:     i18n.StrengthStrong,
+	StrengthVeryStrong: i18n.StrengthVeryStrong,
+}
+
+// String returns the rating as displayed to the user in the default locale of the i18n package, ie: "Very weak"
+func (s Strength) String() string {
+	return i18n.T(s.MessageID())
+}
+
+// MessageID returns the i18n message naming the rating, ie: for a Localizer of another locale
+func (s Strength) MessageID() i18n.MessageID {
+	if id, ok := strengthMessages[s]; ok {
+		return id
+	}
+	return i18n.StrengthUnknown
+}
+
+// DenyList is a set of common passwords which are never accepted. Entries are compared ignoring case
+type DenyList map[string]struct{}
+
+// MakeDenyList is a helper function used to assemble a DenyList from passwords
+func MakeDenyList(passwords ...string) DenyList {
+	d := make(DenyList)
+	for _, p := range passwords {
+		d[strings.ToLower(p)] = struct{}{}
+	}
+	return d
+}
+
+// LoadDenyList reads a DenyList from a local file holding one password per line. Blank lines and lines starting with
+// "#" are ignored
+func LoadDenyList(name string) (DenyList, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d := make(DenyList)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, denyListCommentPrefix) {
+			continue
+		}
+		d[strings.ToLower(line)] = struct{}{}
+	}
+	return d, s.Err()
+}
+
+// Contains reports if password is on the DenyList
+func (d DenyList) Contains(password string) bool {
+	_, ok := d[strings.ToLower(password)]
+	return ok
+}
+
+// PasswordPolicy describes the requirements of a new password. Use Validator to plug it into a Prompt's
+// InputErrorValidatorFunc
+type PasswordPolicy struct {
+	MinLength      int      // Minimum number of characters
+	RequireLower   bool     // If set, a lowercase letter is required
+	RequireUpper   bool     // If set, an uppercase letter is required
+	RequireDigit   bool     // If set, a digit is required
+	RequireSymbol  bool     // If set, a character other than a letter, digit or space is required
+	MinEntropyBits float64  // Minimum entropy estimated by EstimateEntropy
+	DenyList       DenyList // Passwords which are never accepted, ie: loaded with LoadDenyList
+	Locale         string   // Locale of the error messages, ie: "de". This will default to the locale of the i18n package
+}
+
+// PasswordPolicyError lists every rule of a PasswordPolicy a password failed
+type PasswordPolicyError struct {
+	Failures []string // Explanations of the failed rules, ie: "must contain a digit"
+	Locale   string   // Locale of the sentence returned by Error
+}
+
+// Error returns the failures as a sentence, ie: "Password must be at least 12 characters, must contain a digit"
+func (e *PasswordPolicyError) Error() string {
+	l := i18n.DefaultCatalog.Localizer(e.Locale)
+	return l.Message(i18n.PolicyError, strings.Join(e.Failures, l.Message(i18n.PolicySeparator)))
+}
+
+// Validate returns a *PasswordPolicyError explaining which rules password failed, or nil if it meets the policy
+func (p PasswordPolicy) Validate(password string) error {
+	l := i18n.DefaultCatalog.Localizer(p.Locale)
+	var failures []string
+	if n := len([]rune(password)); n < p.MinLength {
+		failures = append(failures, l.Message(i18n.PolicyMinLength, p.MinLength))
+	}
+	classes := []struct {
+		required bool
+		is       func(rune) bool
+		rule     i18n.MessageID
+	}{
+		{p.RequireLower, unicode.IsLower, i18n.PolicyLower},
+		{p.RequireUpper, unicode.IsUpper, i18n.PolicyUpper},
+		{p.RequireDigit, unicode.IsDigit, i18n.PolicyDigit},
+		{p.RequireSymbol, isSymbol, i18n.PolicySymbol},
+	}
+	for _, c := range classes {
+		if c.required && strings.IndexFunc(password, c.is) < 0 {
+			failures = append(failures, l.Message(c.rule))
+		}
+	}
+	if bits := EstimateEntropy(password); bits < p.MinEntropyBits {
+		failures = append(failures, l.Message(i18n.PolicyEntropy, bits, p.MinEntropyBits))
+	}
+	if p.DenyList.Contains(password) {
+		failures = append(failures, l.Message(i18n.PolicyDenyList))
+	}
+	if len(failures) > 0 {
+		return &PasswordPolicyError{Failures: failures, Locale: p.Locale}
+	}
+	return nil
+}
+
+// Validator returns the policy as an InputErrorValidator
+func (p PasswordPolicy) Validator() InputErrorValidator {
+	return p.Validate
+}
+
+// Strength rates password like EstimateStrength, except that passwords on the DenyList are StrengthVeryWeak
+func (p PasswordPolicy) Strength(password string) Strength {
+	if p.DenyList.Contains(password) {
+		return StrengthVeryWeak
+	}
+	return EstimateStrength(password)
+}
+
+// EstimateStrength rates password by its estimated entropy
+func EstimateStrength(password string) Strength {
+	bits := EstimateEntropy(password)
+	switch {
+	case bits >= veryStrongEntropyBits:
+		return StrengthVeryStrong
+	case bits >= strongEntropyBits:
+		return StrengthStrong
+	case bits >= fairEntropyBits:
+		return StrengthFair
+	case bits >= weakEntropyBits:
+		return StrengthWeak
+	}
+	return StrengthVeryWeak
+}
+
+// EstimateEntropy estimates the entropy of password in bits from the size of the character pools it draws from and
+// its length. Runes repeating or continuing a sequence of their predecessor, ie: "aaa" or "abc", count half
+func EstimateEntropy(password string) float64 {
+	var (
+		lower, upper, digit, symbol, other bool
+		length                             float64
+		prev                               rune = -1
+	)
+	for _, r := range password {
+		switch {
+		case r < unicode.MaxASCII && unicode.IsLower(r):
+			lower = true
+		case r < unicode.MaxASCII && unicode.IsUpper(r):
+			upper = true
+		case r < unicode.MaxASCII && unicode.IsDigit(r):
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+		if r == prev || r == prev+1 || r == prev-1 {
+			length += predictableRuneWeight
+		} else {
+			length += regularRuneWeight
+		}
+		prev = r
+	}
+	pool := 0
+	for _, c := range []struct {
+		present bool
+		size    int
+	}{{lower, lowerPoolSize}, {upper, upperPoolSize}, {digit, digitPoolSize}, {symbol, symbolPoolSize}, {other, otherPoolSize}} {
+		if c.present {
+			pool += c.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return length * math.Log2(float64(pool))
+}
+
+func isSymbol(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+}
@@ -0,0 +1,130 @@
+package validation
+
+import (
+	"errors"
+	"github.com/bchivari/go-cli-prompt/i18n"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := PasswordPolicy{
+		MinLength:      10,
+		RequireLower:   true,
+		RequireUpper:   true,
+		RequireDigit:   true,
+		RequireSymbol:  true,
+		MinEntropyBits: 50,
+		DenyList:       MakeDenyList("Password123!"),
+	}
+	tests := []struct {
+		name         string
+		password     string
+		wantFailures []string
+	}{
+		{name: "Valid", password: "Tr0ub4dor&3x", wantFailures: nil},
+		{name: "Too short", password: "aB3$efgh", wantFailures: []string{"must be at least 10 characters", "is too predictable (estimated 43 bits of entropy, 50 required)"}},
+		{
+			name:     "Missing classes",
+			password: "abcdefghijkl",
+			wantFailures: []string{
+				"must contain an uppercase letter",
+				"must contain a digit",
+				"must contain a symbol",
+				"is too predictable (estimated 31 bits of entropy, 50 required)",
+			},
+		},
+		{name: "Denied ignoring case", password: "PASSWORD123!", wantFailures: []string{"must contain a lowercase letter", "is a commonly used password"}},
+		{name: "Unicode length", password: "Ünïcødé$1Aa", wantFailures: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validator()(tt.password)
+			if tt.wantFailures == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			var policyErr *PasswordPolicyError
+			if !errors.As(err, &policyErr) || !reflect.DeepEqual(policyErr.Failures, tt.wantFailures) {
+				t.Errorf("Validate() error = %v, want failures %q", err, tt.wantFailures)
+			}
+		})
+	}
+
+	err := PasswordPolicy{MinLength: 4, RequireDigit: true}.Validate("ab")
+	if want := "Password must be at least 4 characters, must contain a digit"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+
+	err = PasswordPolicy{MinLength: 4, RequireDigit: true, Locale: "de"}.Validate("ab")
+	if want := "Das Passwort muss mindestens 4 Zeichen lang sein, muss eine Ziffer enthalten"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+	err = PasswordPolicy{RequireDigit: true, RequireUpper: true, Locale: "ja_JP.UTF-8"}.Validate("ab")
+	if want := "パスワードは大文字を含めてください、数字を含めてください"; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %q", err, want)
+	}
+}
+
+func TestStrength_String(t *testing.T) {
+	if got := StrengthFair.String(); got != "Fair" {
+		t.Errorf("String() = %q, want %q", got, "Fair")
+	}
+	if got := Strength(42).String(); got != "Unknown" {
+		t.Errorf("String() = %q, want %q", got, "Unknown")
+	}
+	i18n.SetLocale("de")
+	defer i18n.SetLocale(i18n.FallbackLocale)
+	if got := StrengthVeryStrong.String(); got != "Sehr stark" {
+		t.Errorf("String() = %q, want %q", got, "Sehr stark")
+	}
+}
+
+func TestEstimateStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		want     Strength
+	}{
+		{password: "", want: StrengthVeryWeak},
+		{password: "aaaaaaaa", want: StrengthVeryWeak},
+		{password: "abcdefgh", want: StrengthVeryWeak},
+		{password: "qwkdmz", want: StrengthWeak},
+		{password: "qwkdmzpR", want: StrengthFair},
+		{password: "Tr0ub4dor&3", want: StrengthStrong},
+		{password: "correct horse battery staple", want: StrengthVeryStrong},
+	}
+	for _, tt := range tests {
+		if got := EstimateStrength(tt.password); got != tt.want {
+			t.Errorf("EstimateStrength(%q) = %v (%.1f bits), want %v", tt.password, got, EstimateEntropy(tt.password), tt.want)
+		}
+	}
+	policy := PasswordPolicy{DenyList: MakeDenyList("correct horse battery staple")}
+	if got := policy.Strength("correct horse battery staple"); got != StrengthVeryWeak {
+		t.Errorf("Strength() of denied password = %v, want %v", got, StrengthVeryWeak)
+	}
+	if got := Strength(42).String(); got != "Unknown" {
+		t.Errorf("String() = %q, want %q", got, "Unknown")
+	}
+}
+
+func TestLoadDenyList(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "common.txt")
+	os.WriteFile(name, []byte("# Top passwords\n123456\n\n  Qwerty \npassword\n"), 0600)
+	d, err := LoadDenyList(name)
+	if err != nil {
+		t.Fatalf("LoadDenyList() error = %v", err)
+	}
+	if want := MakeDenyList("123456", "qwerty", "password"); !reflect.DeepEqual(d, want) {
+		t.Errorf("LoadDenyList() = %v, want %v", d, want)
+	}
+	if !d.Contains("QWERTY") || d.Contains("# Top passwords") {
+		t.Errorf("Contains() mismatch for %v", d)
+	}
+	if _, err := LoadDenyList(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("LoadDenyList() error = nil, want error")
+	}
+}